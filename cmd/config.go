@@ -7,11 +7,42 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"altum/internal/notes"
 )
 
+var configKeys = []string{
+	"daily_notes_folder_path",
+	"date_format",
+	"daily_note_template",
+	"section_heading",
+	"section_heading_level",
+	"section_position",
+	"entry_format",
+	"entry_template",
+	"midnight_policy",
+	"day_starts_at",
+	"timezone",
+	"vault_search_paths",
+	"weekly_notes_folder_path",
+	"weekly_note_format",
+	"monthly_notes_folder_path",
+	"monthly_note_format",
+	"review_section_heading",
+	"stopwords",
+	"synonyms",
+	"coach_model",
+	"coach_base_url",
+	"coach_api_key",
+	"coach_timeout",
+}
+
 var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "Manage configuration settings",
@@ -23,47 +54,30 @@ var configCmd = &cobra.Command{
 var configSetCmd = &cobra.Command{
 	Use:   "set [key] [value]",
 	Short: "Set a configuration value",
-	Long:  `Set a configuration value. Available keys: daily_notes_folder_path, date_format`,
+	Long:  `Set a configuration value. Available keys: ` + strings.Join(configKeys, ", "),
 	Args:  cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
 		value := args[1]
 
-		validKeys := map[string]bool{
-			"daily_notes_folder_path": true,
-			"date_format":             true,
-		}
-		if !validKeys[key] {
-			fmt.Fprintf(os.Stderr, "Error: Invalid key '%s'. Valid keys are: daily_notes_folder_path, date_format\n", key)
+		if !slices.Contains(configKeys, key) {
+			fmt.Fprintf(os.Stderr, "Error: Invalid key '%s'. Valid keys are: %s\n", key, strings.Join(configKeys, ", "))
 			os.Exit(1)
 		}
-
-		configHome := os.ExpandEnv("$HOME/.config")
-		if configHome == "$HOME/.config" {
-			home, _ := os.UserHomeDir()
-			configHome = filepath.Join(home, ".config")
+		if key == "midnight_policy" {
+			if _, err := notes.ParseMidnightPolicy(value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
 		}
-		altumConfigDir := filepath.Join(configHome, "altum")
-		configFile := filepath.Join(altumConfigDir, "config.yaml")
 
-		if err := os.MkdirAll(altumConfigDir, 0755); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Failed to create config directory: %v\n", err)
+		configFile, err := saveConfigValues(map[string]string{key: value})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		viper.SetConfigFile(configFile)
-		viper.SetConfigType("yaml")
-		if err := viper.ReadInConfig(); err != nil {
-		}
-
-		viper.Set(key, value)
-
-		if err := viper.WriteConfigAs(configFile); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Failed to write config file: %v\n", err)
-			os.Exit(1)
-		}
-
-		fmt.Printf("Set %s = %s\n", key, value)
+		fmt.Printf("Set %s = %s\n", key, configValue(key))
 		fmt.Printf("Configuration saved to: %s\n", configFile)
 	},
 }
@@ -76,11 +90,12 @@ var configGetCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
 			fmt.Println("Current configuration:")
-			fmt.Printf("  daily_notes_folder_path: %s\n", viper.GetString("daily_notes_folder_path"))
-			fmt.Printf("  date_format: %s\n", viper.GetString("date_format"))
+			for _, key := range configKeys {
+				fmt.Printf("  %s: %s\n", key, configValue(key))
+			}
 		} else {
 			key := args[0]
-			value := viper.GetString(key)
+			value := configValue(key)
 			if value == "" {
 				fmt.Printf("%s is not set\n", key)
 			} else {
@@ -90,8 +105,154 @@ var configGetCmd = &cobra.Command{
 	},
 }
 
+var configDetectCmd = &cobra.Command{
+	Use:   "detect [path]",
+	Short: "Detect daily notes settings from an Obsidian vault",
+	Long: `Detect an Obsidian vault and import its daily notes folder, date format and template.
+
+The vault is found by walking up from the given path (or the current directory), falling back to
+searching vault_search_paths. Settings are read from the core Daily notes plugin, or from the
+Periodic Notes plugin when its daily notes are enabled. Weekly and monthly note settings are imported
+from Periodic Notes too, for altum review.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		start := "."
+		if len(args) == 1 {
+			start = args[0]
+		}
+
+		yes, _ := cmd.Flags().GetBool("yes")
+		vault, ok, err := detectVault(cmd, start)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !ok {
+			fmt.Fprintln(os.Stderr, "No Obsidian vault found. Add folders to search with:")
+			fmt.Fprintln(os.Stderr, "  altum config set vault_search_paths ~/Documents,~/Obsidian")
+			os.Exit(1)
+		}
+
+		if _, err := offerVaultSettings(cmd, vault, yes); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// configValue returns a setting for display, hiding all but the end of the
+// coach's API key.
+func configValue(key string) string {
+	value := viper.GetString(key)
+	if key == "coach_api_key" && len(value) > 4 {
+		return strings.Repeat("*", 8) + value[len(value)-4:]
+	}
+	return value
+}
+
+func saveConfigValues(values map[string]string) (string, error) {
+	configFile := cfgFile
+	if configFile == "" {
+		configHome := os.ExpandEnv("$HOME/.config")
+		if configHome == "$HOME/.config" {
+			home, _ := os.UserHomeDir()
+			configHome = filepath.Join(home, ".config")
+		}
+		configFile = filepath.Join(configHome, "altum", "config.yaml")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	viper.SetConfigFile(configFile)
+	viper.SetConfigType("yaml")
+	if err := viper.ReadInConfig(); err != nil {
+	}
+
+	for key, value := range values {
+		viper.Set(key, value)
+	}
+
+	if err := viper.WriteConfigAs(configFile); err != nil {
+		return "", fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return configFile, nil
+}
+
+// requireNotesConfig returns the daily notes settings, offering to import them
+// from a detected Obsidian vault when none are configured yet.
+func requireNotesConfig(cmd *cobra.Command) notes.Config {
+	notesConfig := loadNotesConfig()
+
+	if notesConfig.FolderPath == "" && viper.ConfigFileUsed() == "" {
+		if vault, ok, err := detectVault(cmd, "."); err == nil && ok {
+			if saved, err := offerVaultSettings(cmd, vault, false); err == nil && saved {
+				notesConfig = loadNotesConfig()
+			}
+		}
+	}
+
+	if notesConfig.FolderPath == "" {
+		fmt.Fprintf(os.Stderr, "Error: daily_notes_folder_path is required. Please set it using:\n")
+		fmt.Fprintf(os.Stderr, "  altum config set daily_notes_folder_path <folder_path>\n")
+		fmt.Fprintf(os.Stderr, "  altum config detect\n")
+		fmt.Fprintf(os.Stderr, "  or use --daily_notes_folder_path flag\n")
+		os.Exit(1)
+	}
+
+	return notesConfig
+}
+
+func loadNotesConfig() notes.Config {
+	format, err := notes.LoadEntryFormat(viper.GetString("entry_format"), expandHome(viper.GetString("entry_template")))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	dayStartsAt, err := notes.ParseDayStart(viper.GetString("day_starts_at"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	midnightPolicy, err := notes.ParseMidnightPolicy(viper.GetString("midnight_policy"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	location := time.Local
+	if timezone := viper.GetString("timezone"); timezone != "" {
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid timezone %q: %v\n", timezone, err)
+			os.Exit(1)
+		}
+	}
+
+	return notes.Config{
+		FolderPath:   viper.GetString("daily_notes_folder_path"),
+		DateFormat:   notes.ParseDateFormat(viper.GetString("date_format")),
+		TemplatePath: viper.GetString("daily_note_template"),
+		Section: notes.Section{
+			Heading:  viper.GetString("section_heading"),
+			Level:    viper.GetInt("section_heading_level"),
+			Position: viper.GetString("section_position"),
+		},
+		Format:         format,
+		MidnightPolicy: midnightPolicy,
+		DayStartsAt:    dayStartsAt,
+		Location:       location,
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configDetectCmd)
+	configDetectCmd.Flags().BoolP("yes", "y", false, "Save detected settings without asking")
 }
@@ -4,316 +4,455 @@ Copyright © 2025 Eden Phillips
 package cmd
 
 import (
-	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-)
-
-var daysFlag int
 
-type Session struct {
-	Date         time.Time
-	Duration     time.Duration
-	FocusQuality int
-	Milestone    string
-}
+	"altum/internal/notes"
+	"altum/internal/report"
+	"altum/internal/tui/dashboard"
+)
 
-type DayStats struct {
-	Date     time.Time
-	Sessions int
-	Duration time.Duration
-}
+var (
+	daysFlag       int
+	fastFlag       bool
+	fromFlag       string
+	toFlag         string
+	weekFlag       string
+	monthFlag      string
+	quarterFlag    string
+	yearFlag       string
+	dateFlag       string
+	formatFlag     string
+	sessionsFlag   bool
+	asciiFlag      bool
+	heatmapFlag    bool
+	compareFlag    string
+	tuiFlag        bool
+	milestonesFlag bool
+	searchFlag     string
+	watchFlag      bool
+)
 
 var reportCmd = &cobra.Command{
 	Use:   "report",
 	Short: "Generate a report of your deep work sessions",
-	Long:  `Generate a report of your deep work sessions for the last N days. Shows statistics including total sessions, time spent, average ratings, and more.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		dailyNotesFolderPath := viper.GetString("daily_notes_folder_path")
-		dateFormat := viper.GetString("date_format")
+	Long: `Generate a report of your deep work sessions. Shows statistics including total sessions, time spent, average ratings, and more.
 
-		if dailyNotesFolderPath == "" {
-			fmt.Fprintf(os.Stderr, "Error: daily_notes_folder_path is required. Please set it using:\n")
-			fmt.Fprintf(os.Stderr, "  altum config set daily_notes_folder_path <folder_path>\n")
-			fmt.Fprintf(os.Stderr, "  or use --daily_notes_folder_path flag\n")
-			os.Exit(1)
-		}
+By default the report covers the last 7 days. Choose another range with:
+  --days N                  the last N days
+  --from 2025-11-01 --to 2025-11-15
+  --week this|last|2025-W46
+  --month this|last|2025-11
+  --quarter this|last|2025-Q4
+  --year this|last|2025
+  --date 2025-11-15         a detailed view of a single day (or today, yesterday)
 
-		if dateFormat == "" {
-			dateFormat = "2006-01-02"
-		}
+With --fast, daily totals are read from the deep_work_* frontmatter properties Altum keeps up to date,
+falling back to the session entries for notes without them. Sections built from individual sessions,
+such as milestones and time of day, are left out.
 
-		sessions, err := parseSessions(dailyNotesFolderPath, dateFormat, daysFlag)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error parsing sessions: %v\n", err)
-			os.Exit(1)
-		}
+Use --format to write the report as json, csv (one row per session), markdown or text (the default).
+JSON includes every aggregate, and a row per session with --sessions.
 
-		if len(sessions) == 0 {
-			fmt.Printf("No sessions found in the last %d days.\n", daysFlag)
-			return
-		}
+Reports compare the range with the period before it: the previous week, month and so on, cut to the
+days passed so far while the range is in progress. Compare with another period with --compare RANGE,
+such as 2025-W45, 2025-10, 2025-Q3 or 2025-10-01..2025-10-15, or turn it off with --compare none.
 
-		printReport(sessions, daysFlag)
+Use --milestones for a digest of the milestones you logged, grouped by day, and --search=TEXT to find
+particular ones. With --format markdown the digest is a checklist, ready for a stand-up or review.
+
+Use --heatmap or the heatmap subcommand for a calendar view of the last 52 weeks, and --tui to browse
+the range in an interactive dashboard.
+
+With --watch, the report is redrawn whenever a daily note is saved, such as when a session is logged
+from another terminal or a note is edited in Obsidian. Only the changed notes are read again.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runReport(cmd, heatmapFlag)
+	},
+}
+
+var reportHeatmapCmd = &cobra.Command{
+	Use:   "heatmap",
+	Short: "Show a calendar heatmap of your deep work",
+	Long: `Show a calendar heatmap of your deep work, with a column per week and a row per weekday shaded
+by the hours worked that day. Covers the last 52 weeks unless a range flag is given.
+
+Days are coloured when the terminal supports it (set NO_COLOR to turn this off), and shaded with
+block characters otherwise.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runReport(cmd, true)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(reportCmd)
-	reportCmd.Flags().IntVarP(&daysFlag, "days", "d", 7, "Number of days to include in the report")
+	reportCmd.AddCommand(reportHeatmapCmd)
+
+	flags := reportCmd.PersistentFlags()
+	flags.IntVarP(&daysFlag, "days", "d", 7, "Number of days to include in the report")
+	flags.BoolVar(&fastFlag, "fast", false, "Read daily totals from note frontmatter when available")
+	flags.StringVar(&fromFlag, "from", "", "First day of the report (YYYY-MM-DD)")
+	flags.StringVar(&toFlag, "to", "", "Last day of the report (YYYY-MM-DD, default today)")
+	flags.StringVar(&weekFlag, "week", "", "ISO week to report on: this, last or YYYY-Www")
+	flags.StringVar(&monthFlag, "month", "", "Month to report on: this, last or YYYY-MM")
+	flags.StringVar(&quarterFlag, "quarter", "", "Quarter to report on: this, last or YYYY-Qn")
+	flags.StringVar(&yearFlag, "year", "", "Year to report on: this, last or YYYY")
+	flags.BoolVar(&asciiFlag, "ascii", false, "Draw charts, ratings and rules with plain ASCII characters")
+	flags.BoolVar(&watchFlag, "watch", false, "Redraw the report whenever daily notes change")
+
+	reportCmd.Flags().StringVarP(&formatFlag, "format", "f", report.FormatText, "Output format: text, json, csv or markdown")
+	reportCmd.Flags().BoolVar(&sessionsFlag, "sessions", false, "Include a row per session in JSON output")
+	reportCmd.Flags().BoolVar(&heatmapFlag, "heatmap", false, "Show a calendar heatmap instead (see altum report heatmap)")
+	reportCmd.Flags().StringVar(&compareFlag, "compare", comparePrevious, "Period to compare with: previous, none, or a range such as 2025-W45, 2025-10 or 2025-10-01..2025-10-15")
+	reportCmd.Flags().StringVar(&dateFlag, "date", "", "Show a detailed report of a single day (YYYY-MM-DD, today or yesterday)")
+	reportCmd.Flags().BoolVar(&tuiFlag, "tui", false, "Browse the report in an interactive dashboard")
+	reportCmd.Flags().BoolVar(&milestonesFlag, "milestones", false, "List the milestones of the range, grouped by day")
+	reportCmd.Flags().StringVar(&searchFlag, "search", "", "List only milestones containing every word of this text (implies --milestones)")
+
+	reportCmd.MarkFlagsMutuallyExclusive("days", "from", "week", "month", "quarter", "year", "date")
+	reportCmd.MarkFlagsMutuallyExclusive("to", "week", "month", "quarter", "year", "date")
+	reportCmd.MarkFlagsMutuallyExclusive("heatmap", "date")
+	reportCmd.MarkFlagsMutuallyExclusive("heatmap", "format")
+	reportCmd.MarkFlagsMutuallyExclusive("heatmap", "compare")
+	reportCmd.MarkFlagsMutuallyExclusive("tui", "heatmap")
+	reportCmd.MarkFlagsMutuallyExclusive("tui", "format")
+	reportCmd.MarkFlagsMutuallyExclusive("tui", "sessions")
+	reportCmd.MarkFlagsMutuallyExclusive("tui", "compare")
+	reportCmd.MarkFlagsMutuallyExclusive("tui", "watch")
+	for _, name := range []string{"milestones", "search"} {
+		reportCmd.MarkFlagsMutuallyExclusive(name, "heatmap")
+		reportCmd.MarkFlagsMutuallyExclusive(name, "tui")
+		reportCmd.MarkFlagsMutuallyExclusive(name, "sessions")
+		reportCmd.MarkFlagsMutuallyExclusive(name, "compare")
+	}
+	reportHeatmapCmd.MarkFlagsMutuallyExclusive("days", "from", "week", "month", "quarter", "year")
+	reportHeatmapCmd.MarkFlagsMutuallyExclusive("to", "week", "month", "quarter", "year")
 }
 
-func parseSessions(dailyNotesPath, dateFormat string, days int) ([]Session, error) {
-	var sessions []Session
-	now := time.Now()
-
-	dateMap := make(map[string]bool)
-	for i := 0; i < days; i++ {
-		date := now.AddDate(0, 0, -i)
-		dateStr := date.Format(dateFormat)
-		dateMap[dateStr] = true
-	}
+func runReport(cmd *cobra.Command, heatmap bool) {
+	notesConfig := requireNotesConfig(cmd)
 
-	files, err := os.ReadDir(dailyNotesPath)
+	reportRange, err := currentReportRange(cmd, notesConfig.Today(), heatmap)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read daily notes directory: %w", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	sessionRe := regexp.MustCompile(`^#### Session \d+$`)
-	durationRe := regexp.MustCompile(`^- Duration: (\d+) minutes (\d+) seconds$`)
-	focusQualityRe := regexp.MustCompile(`^- Focus Quality: (\d+)/5$`)
-
-	for _, file := range files {
-		if file.IsDir() || !strings.HasSuffix(file.Name(), ".md") {
-			continue
+	if tuiFlag {
+		if err := dashboard.Run(notesConfig, reportRange, chartCharset()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running dashboard: %v\n", err)
+			os.Exit(1)
 		}
+		return
+	}
 
-		filename := strings.TrimSuffix(file.Name(), ".md")
-
-		fileDate, err := time.Parse(dateFormat, filename)
+	format := report.FormatText
+	if !heatmap {
+		format, err = report.ParseFormat(formatFlag)
 		if err != nil {
-			continue
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
+	}
+	opts := report.RenderOptions{
+		Sessions:   sessionsFlag,
+		Width:      terminalWidth(),
+		ASCII:      asciiCharts(),
+		Color:      colorOutput(),
+		Vocabulary: reportVocabulary(),
+	}
 
-		dateStr := fileDate.Format(dateFormat)
-		if !dateMap[dateStr] {
-			continue
-		}
+	if watchFlag {
+		watchReport(cmd, notesConfig, heatmap, format, opts)
+		return
+	}
 
-		filePath := filepath.Join(dailyNotesPath, file.Name())
-		fileSessions, err := parseFileSessions(filePath, fileDate, sessionRe, durationRe, focusQualityRe)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to parse %s: %v\n", file.Name(), err)
-			continue
+	// Both ranges are read from the same notes, so warnings are shown once.
+	warned := make(map[string]bool)
+	err = writeReport(os.Stdout, cmd, notesConfig, heatmap, format, opts, func(warning error) {
+		if !warned[warning.Error()] {
+			warned[warning.Error()] = true
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", warning)
 		}
-
-		sessions = append(sessions, fileSessions...)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
-
-	return sessions, nil
 }
 
-func parseFileSessions(filePath string, fileDate time.Time, sessionRe, durationRe, focusQualityRe *regexp.Regexp) ([]Session, error) {
-	file, err := os.Open(filePath)
+// writeReport loads the range and the period it is compared with and writes
+// the report. The range is resolved again on each call, so that a watched
+// report moves on with the day.
+func writeReport(w io.Writer, cmd *cobra.Command, notesConfig notes.Config, heatmap bool, format string, opts report.RenderOptions, warn func(error)) error {
+	today := notesConfig.Today()
+	reportRange, err := currentReportRange(cmd, today, heatmap)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer file.Close()
 
-	var sessions []Session
-	scanner := bufio.NewScanner(file)
+	// Totals from frontmatter can't tell sessions apart, so views that
+	// list them always parse the notes. The compared period is read the
+	// same way, so that both are measured alike.
+	milestones := milestonesFlag || cmd.Flags().Changed("search")
+	fast := fastFlag && reportRange.Kind != report.RangeDay && !report.NeedsSessions(format, opts) && !milestones
 
-	var currentSession *Session
-	inSessionsSection := false
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+	stats, warnings, err := report.Load(notesConfig, reportRange, fast)
+	if err != nil {
+		return fmt.Errorf("failed to parse sessions: %w", err)
+	}
+	for _, warning := range warnings {
+		warn(warning)
+	}
 
-		if strings.HasPrefix(line, "## Altum Work Sessions") {
-			inSessionsSection = true
-			continue
+	if milestones {
+		if err := report.RenderMilestones(w, format, stats, opts, searchFlag); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
 		}
+		return nil
+	}
 
-		if !inSessionsSection {
-			continue
+	// A single day is only compared when asked to.
+	if !heatmap && (reportRange.Kind != report.RangeDay || cmd.Flags().Changed("compare")) {
+		compareRange, ok, err := resolveCompareRange(reportRange, today)
+		if err != nil {
+			return err
 		}
-
-		if sessionRe.MatchString(line) {
-			if currentSession != nil {
-				sessions = append(sessions, *currentSession)
+		if ok {
+			previous, warnings, err := report.Load(notesConfig, compareRange, fast)
+			if err != nil {
+				return fmt.Errorf("failed to parse sessions: %w", err)
 			}
-			currentSession = &Session{
-				Date: fileDate,
+			for _, warning := range warnings {
+				warn(warning)
 			}
-			continue
-		}
-
-		if currentSession == nil {
-			continue
-		}
-
-		if matches := durationRe.FindStringSubmatch(line); matches != nil {
-			minutes, _ := strconv.Atoi(matches[1])
-			seconds, _ := strconv.Atoi(matches[2])
-			currentSession.Duration = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
-			continue
-		}
-
-		if matches := focusQualityRe.FindStringSubmatch(line); matches != nil {
-			quality, _ := strconv.Atoi(matches[1])
-			currentSession.FocusQuality = quality
-			continue
-		}
-
-		if strings.HasPrefix(line, "- Milestone: ") {
-			currentSession.Milestone = strings.TrimPrefix(line, "- Milestone: ")
-			continue
+			stats.Previous = previous
 		}
 	}
 
-	if currentSession != nil {
-		sessions = append(sessions, *currentSession)
+	if heatmap {
+		err = report.RenderHeatmap(w, stats, opts)
+	} else {
+		err = report.Render(w, format, stats, opts)
 	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
 	}
-
-	return sessions, nil
+	return nil
 }
 
-func printReport(sessions []Session, days int) {
-	if len(sessions) == 0 {
-		return
-	}
-
-	var totalDuration time.Duration
-	var totalFocusQuality int
-	var focusQualityCount int
-	longestSession := sessions[0]
-
-	dayStatsMap := make(map[string]*DayStats)
-
-	for _, session := range sessions {
-		totalDuration += session.Duration
+// watchReport redraws the report whenever daily notes change, until
+// interrupted. Only changed notes are parsed again, thanks to the index.
+func watchReport(cmd *cobra.Command, notesConfig notes.Config, heatmap bool, format string, opts report.RenderOptions) {
+	output := termenv.NewOutput(os.Stdout)
+	interactive := term.IsTerminal(os.Stdout.Fd())
+
+	refresh := func(changed []notes.NoteFile) {
+		var b bytes.Buffer
+		var warnings []error
+		warned := make(map[string]bool)
+
+		opts.Width = terminalWidth()
+		err := writeReport(&b, cmd, notesConfig, heatmap, format, opts, func(warning error) {
+			if !warned[warning.Error()] {
+				warned[warning.Error()] = true
+				warnings = append(warnings, warning)
+			}
+		})
 
-		if session.FocusQuality > 0 {
-			totalFocusQuality += session.FocusQuality
-			focusQualityCount++
+		// The report is drawn in one write to keep the screen from
+		// flickering.
+		if interactive {
+			output.ClearScreen()
 		}
-
-		if session.Duration > longestSession.Duration {
-			longestSession = session
+		os.Stdout.Write(b.Bytes())
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", warning)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		}
 
-		dateStr := session.Date.Format("2006-01-02")
-		if dayStatsMap[dateStr] == nil {
-			dayStatsMap[dateStr] = &DayStats{
-				Date: session.Date,
+		status := "Updated " + time.Now().Format("15:04:05")
+		if len(changed) > 0 {
+			var names []string
+			for _, file := range changed {
+				names = append(names, notesConfig.NoteTitle(file.Date))
 			}
+			status += " after changes to " + strings.Join(names, ", ")
 		}
-		dayStatsMap[dateStr].Sessions++
-		dayStatsMap[dateStr].Duration += session.Duration
+		fmt.Fprintf(os.Stderr, "\n%s. Watching %s for changes, press Ctrl+C to stop.\n", status, notesConfig.FolderPath)
 	}
 
-	var dayStats []*DayStats
-	for _, stats := range dayStatsMap {
-		dayStats = append(dayStats, stats)
-	}
-
-	var bestDay *DayStats
-	if len(dayStats) > 0 {
-		bestDay = dayStats[0]
-		for _, stats := range dayStats {
-			if stats.Duration > bestDay.Duration {
-				bestDay = stats
-			}
-		}
+	refresh(nil)
+	if err := notesConfig.Watch(refresh); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to watch daily notes: %v\n", err)
+		os.Exit(1)
 	}
+}
 
-	avgDuration := totalDuration / time.Duration(len(sessions))
-	avgFocusQuality := 0.0
-	if focusQualityCount > 0 {
-		avgFocusQuality = float64(totalFocusQuality) / float64(focusQualityCount)
+// currentReportRange resolves the range flags against today. Without one,
+// reports cover the last --days days and the heatmap the last 52 weeks.
+func currentReportRange(cmd *cobra.Command, today time.Time, heatmap bool) (report.Range, error) {
+	fallback := report.LastDays(today, daysFlag)
+	if heatmap {
+		fallback = report.LastWeeks(today, 52)
 	}
+	return resolveReportRange(cmd, today, fallback)
+}
 
-	totalHours := totalDuration.Hours()
-	totalMinutes := int(totalDuration.Minutes())
-	avgMinutes := int(avgDuration.Minutes())
+func resolveReportRange(cmd *cobra.Command, today time.Time, fallback report.Range) (report.Range, error) {
+	flags := cmd.Flags()
 
-	now := time.Now()
-	startDate := now.AddDate(0, 0, -days+1)
+	switch {
+	case flags.Changed("date"):
+		date, err := report.ParseDate(dateFlag, today)
+		if err != nil {
+			return report.Range{}, err
+		}
+		return report.Day(date), nil
+	case flags.Changed("week"):
+		return report.ParseWeek(weekFlag, today)
+	case flags.Changed("month"):
+		return report.ParseMonth(monthFlag, today)
+	case flags.Changed("quarter"):
+		return report.ParseQuarter(quarterFlag, today)
+	case flags.Changed("year"):
+		return report.ParseYear(yearFlag, today)
+	case flags.Changed("from") || flags.Changed("to"):
+		to := today
+		if toFlag != "" {
+			date, err := report.ParseDate(toFlag, today)
+			if err != nil {
+				return report.Range{}, err
+			}
+			to = date
+		}
+		if fromFlag == "" {
+			return report.LastDays(to, daysFlag), nil
+		}
+		from, err := report.ParseDate(fromFlag, today)
+		if err != nil {
+			return report.Range{}, err
+		}
+		return report.Between(from, to), nil
+	case flags.Changed("days"):
+		return report.LastDays(today, daysFlag), nil
+	}
 
-	fmt.Println()
-	fmt.Println("═══════════════════════════════════════════════════════════")
-	fmt.Printf("  Deep Work Report: %s - %s\n",
-		startDate.Format("Jan 2, 2006"),
-		now.Format("Jan 2, 2006"))
-	fmt.Println("═══════════════════════════════════════════════════════════")
-	fmt.Println()
+	return fallback, nil
+}
 
-	fmt.Printf("Total sessions: %d\n", len(sessions))
-	fmt.Printf("Total deep work: %.1f hours (%d minutes)\n", totalHours, totalMinutes)
-	fmt.Printf("Average session: %d minutes\n", avgMinutes)
+const (
+	compareNone     = "none"
+	comparePrevious = "previous"
+)
 
-	if focusQualityCount > 0 {
-		fmt.Printf("Average rating: %.1f / 5\n", avgFocusQuality)
+// resolveCompareRange returns the range given by --compare, if any.
+func resolveCompareRange(reportRange report.Range, today time.Time) (report.Range, bool, error) {
+	switch strings.ToLower(compareFlag) {
+	case compareNone, "":
+		return report.Range{}, false, nil
+	case comparePrevious, "last":
+		return report.PreviousPeriod(reportRange, today), true, nil
 	}
-
-	if bestDay != nil {
-		bestHours := bestDay.Duration.Hours()
-		fmt.Printf("Best day: %s – %.1f hours (%d sessions)\n",
-			bestDay.Date.Format("Jan 2"),
-			bestHours,
-			bestDay.Sessions)
+	compareRange, err := report.ParseRange(compareFlag, today)
+	if err != nil {
+		return report.Range{}, false, err
 	}
+	return compareRange, true, nil
+}
 
-	longestMinutes := int(longestSession.Duration.Minutes())
-	fmt.Printf("Longest session: %d minutes (%s)\n",
-		longestMinutes,
-		longestSession.Date.Format("Jan 2"))
+// terminalWidth returns the width of the terminal the report is printed to,
+// falling back to $COLUMNS when stdout isn't a terminal.
+func terminalWidth() int {
+	if width, _, err := term.GetSize(os.Stdout.Fd()); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return report.DefaultWidth
+}
 
-	daysWithWork := len(dayStats)
-	fmt.Printf("Days with deep work: %d / %d (%.0f%%)\n",
-		daysWithWork,
-		days,
-		float64(daysWithWork)/float64(days)*100)
+// colorOutput reports whether stdout is a terminal that shows colour and
+// NO_COLOR isn't set.
+func colorOutput() bool {
+	return os.Getenv("NO_COLOR") == "" && lipgloss.ColorProfile() != termenv.Ascii
+}
 
-	if focusQualityCount > 0 {
-		fmt.Printf("Total rating points: %d\n", totalFocusQuality)
+// reportVocabulary reads the stopwords and synonyms used to find recurring
+// interruptions and reflection themes. Synonyms are given as word=term pairs,
+// e.g. teams=slack, and both settings take comma separated lists.
+func reportVocabulary() report.Vocabulary {
+	vocabulary := report.Vocabulary{
+		Stopwords: configList("stopwords"),
+		Synonyms:  make(map[string]string),
 	}
+	for _, pair := range configList("synonyms") {
+		from, to, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring synonym %q, expected word=term\n", pair)
+			continue
+		}
+		vocabulary.Synonyms[strings.TrimSpace(from)] = strings.TrimSpace(to)
+	}
+	return vocabulary
+}
 
-	fmt.Println()
-	fmt.Println("Top performing days:")
+// configList reads a setting given either as a YAML list or as a comma
+// separated string.
+func configList(key string) []string {
+	items := viper.GetStringSlice(key)
+	if value, ok := viper.Get(key).(string); ok {
+		items = []string{value}
+	}
 
-	for i := 0; i < len(dayStats)-1; i++ {
-		for j := 0; j < len(dayStats)-i-1; j++ {
-			if dayStats[j].Duration < dayStats[j+1].Duration {
-				dayStats[j], dayStats[j+1] = dayStats[j+1], dayStats[j]
+	var values []string
+	for _, item := range items {
+		for _, value := range strings.Split(item, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
 			}
 		}
 	}
+	return values
+}
 
-	topDays := 5
-	if len(dayStats) < topDays {
-		topDays = len(dayStats)
-	}
+// asciiCharts reports whether reports should avoid non-ASCII characters.
+func asciiCharts() bool {
+	return asciiFlag || !unicodeLocale()
+}
 
-	for i := 0; i < topDays; i++ {
-		stats := dayStats[i]
-		hours := stats.Duration.Hours()
-		fmt.Printf("%s: %.1fh (%s)\n",
-			stats.Date.Format("Jan 2"),
-			hours)
+// chartCharset is the charset the dashboard draws its charts and ratings with.
+func chartCharset() report.Charset {
+	if asciiCharts() {
+		return report.ASCIICharset
 	}
+	return report.UnicodeCharset
+}
 
-	fmt.Println()
+// unicodeLocale reports whether the locale allows block characters. An unset
+// locale is assumed to be UTF-8, as it is in most modern terminals.
+func unicodeLocale() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			value = strings.ToUpper(value)
+			return strings.Contains(value, "UTF-8") || strings.Contains(value, "UTF8")
+		}
+	}
+	return true
 }
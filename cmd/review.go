@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"altum/internal/notes"
+	"altum/internal/report"
+)
+
+var (
+	reviewWeekFlag  string
+	reviewMonthFlag string
+	reviewPrintFlag bool
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Write a weekly or monthly review note",
+	Long: `Write a review of a week or month into its periodic note in your vault: the period's totals compared
+with the one before, its top days, and the milestones and reflections you logged.
+
+  --week this|last|2025-W46   the week's note (the default is this week)
+  --month this|last|2025-11   the month's note
+
+Notes are named like the Periodic Notes plugin names them, using weekly_note_format (default
+gggg-[W]ww) and monthly_note_format (default YYYY-MM) in weekly_notes_folder_path and
+monthly_notes_folder_path, which default to the daily notes folder. altum config detect imports them
+from Periodic Notes.
+
+The review is written under its own section (review_section_heading, default "Altum Review"). Running
+the review again replaces that section and leaves the rest of the note untouched.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		notesConfig := requireNotesConfig(cmd)
+		today := notesConfig.Today()
+
+		reviewRange, periodicNotes, err := resolveReview(cmd, notesConfig)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		stats, warnings, err := report.Load(notesConfig, reviewRange, false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing sessions: %v\n", err)
+			os.Exit(1)
+		}
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", warning)
+		}
+
+		// The previous period is parsed like the reviewed one, so that
+		// sessions crossing midnight are counted the same way in both.
+		previous, _, err := report.Load(notesConfig, report.PreviousPeriod(reviewRange, today), false)
+		if err == nil {
+			stats.Previous = previous
+		}
+
+		var body strings.Builder
+		if err := report.RenderReview(&body, stats, notesConfig); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing review: %v\n", err)
+			os.Exit(1)
+		}
+
+		section := notes.Section{
+			Heading:  viper.GetString("review_section_heading"),
+			Level:    2,
+			Position: notes.PositionBottom,
+		}
+
+		if reviewPrintFlag {
+			fmt.Println(section.Title())
+			fmt.Println()
+			fmt.Print(body.String())
+			return
+		}
+
+		notePath := periodicNotes.NotePath(reviewRange.Start)
+		if err := notes.SaveReview(notePath, section, body.String(), stats.Total.ReviewProperties()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving review: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Review of %s saved to %s\n", reviewRange.Title(), notePath)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reviewCmd)
+
+	reviewCmd.Flags().StringVar(&reviewWeekFlag, "week", "", "ISO week to review: this, last or YYYY-Www")
+	reviewCmd.Flags().StringVar(&reviewMonthFlag, "month", "", "Month to review: this, last or YYYY-MM")
+	reviewCmd.Flags().BoolVar(&reviewPrintFlag, "print", false, "Print the review instead of saving it")
+	reviewCmd.MarkFlagsMutuallyExclusive("week", "month")
+}
+
+// resolveReview returns the period to review and where its notes are kept.
+func resolveReview(cmd *cobra.Command, notesConfig notes.Config) (report.Range, notes.PeriodicNotes, error) {
+	today := notesConfig.Today()
+
+	if cmd.Flags().Changed("month") {
+		reviewRange, err := report.ParseMonth(reviewMonthFlag, today)
+		return reviewRange, periodicNotes(notesConfig, "monthly_notes_folder_path", "monthly_note_format"), err
+	}
+
+	week := "this"
+	if cmd.Flags().Changed("week") {
+		week = reviewWeekFlag
+	}
+	reviewRange, err := report.ParseWeek(week, today)
+	return reviewRange, periodicNotes(notesConfig, "weekly_notes_folder_path", "weekly_note_format"), err
+}
+
+// periodicNotes reads the folder and format of weekly or monthly notes. The
+// folder defaults to the daily notes folder.
+func periodicNotes(notesConfig notes.Config, folderKey, formatKey string) notes.PeriodicNotes {
+	folder := expandHome(viper.GetString(folderKey))
+	if folder == "" {
+		folder = notesConfig.FolderPath
+	}
+	return notes.PeriodicNotes{
+		FolderPath: folder,
+		DateFormat: notes.ParseDateFormat(viper.GetString(formatKey)),
+	}
+}
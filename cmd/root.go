@@ -23,6 +23,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"altum/internal/coach"
+	"altum/internal/notes"
+	"altum/internal/report"
+	"altum/internal/tui/dashboard"
 	"altum/internal/tui/menu"
 )
 
@@ -47,6 +51,13 @@ designed to help you achieve deeper, more meaningful work.`,
 		switch selected {
 		case menu.MenuStart:
 			startCmd.Run(startCmd, []string{})
+		case menu.MenuReport:
+			notesConfig := requireNotesConfig(cmd)
+			reportRange := report.Week(notesConfig.Today())
+			if err := dashboard.Run(notesConfig, reportRange, chartCharset()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error running dashboard: %v\n", err)
+				os.Exit(1)
+			}
 		case menu.MenuConfig:
 			configCmd.Run(configCmd, []string{})
 		case menu.MenuExit:
@@ -67,7 +78,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.altum.yaml)")
 
 	rootCmd.PersistentFlags().String("daily_notes_folder_path", "", "Path to the daily notes folder (required)")
-	rootCmd.PersistentFlags().String("date_format", "2006-01-02", "Date format for notes (Obsidian format)")
+	rootCmd.PersistentFlags().String("date_format", notes.DefaultDateFormat, "Date format for note names (moment.js format as in Obsidian, or a Go layout)")
 
 	viper.BindPFlag("daily_notes_folder_path", rootCmd.PersistentFlags().Lookup("daily_notes_folder_path"))
 	viper.BindPFlag("date_format", rootCmd.PersistentFlags().Lookup("date_format"))
@@ -93,7 +104,17 @@ func initConfig() {
 	viper.SetEnvPrefix("ALTUM")
 	viper.AutomaticEnv()
 
-	viper.SetDefault("date_format", "2006-01-02")
+	viper.SetDefault("date_format", notes.DefaultDateFormat)
+	viper.SetDefault("section_heading", notes.DefaultSectionHeading)
+	viper.SetDefault("section_heading_level", notes.DefaultSectionLevel)
+	viper.SetDefault("section_position", notes.PositionBottom)
+	viper.SetDefault("entry_format", notes.FormatBullet)
+	viper.SetDefault("midnight_policy", notes.MidnightStart)
+	viper.SetDefault("weekly_note_format", notes.DefaultWeeklyNoteFormat)
+	viper.SetDefault("monthly_note_format", notes.DefaultMonthlyNoteFormat)
+	viper.SetDefault("review_section_heading", notes.DefaultReviewHeading)
+	viper.SetDefault("coach_base_url", coach.DefaultBaseURL)
+	viper.SetDefault("coach_timeout", coach.DefaultTimeout.String())
 
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
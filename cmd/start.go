@@ -11,26 +11,25 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"altum/internal/coach"
 	session "altum/internal/tui/session"
 )
 
+var noCoachFlag bool
+
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start a deep work session",
 	Long: `Start a session for a deep work session. The session will run until you press Enter.
-After stopping, you'll be prompted for a rating, interruptions, reflection and notes about the session.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		dailyNotesFolderPath := viper.GetString("daily_notes_folder_path")
-		dateFormat := viper.GetString("date_format")
+After stopping, you'll be prompted for a rating, interruptions, reflection and notes about the session.
 
-		if dailyNotesFolderPath == "" {
-			fmt.Fprintf(os.Stderr, "Error: daily_notes_folder_path is required. Please set it using:\n")
-			fmt.Fprintf(os.Stderr, "  altum config set daily_notes_folder_path <folder_path>\n")
-			fmt.Fprintf(os.Stderr, "  or use --daily_notes_folder_path flag\n")
-			os.Exit(1)
-		}
+When coach_model is set, a coach first gives you a tip for the session based on your last sessions,
+using any OpenAI-compatible chat API (coach_base_url, default OpenAI, and coach_api_key). If it doesn't
+answer within coach_timeout, you get a general tip instead. Skip it with --no-coach.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		notesConfig := requireNotesConfig(cmd)
 
-		m := session.InitialModel(dailyNotesFolderPath, dateFormat)
+		m := session.InitialModel(notesConfig, sessionCoach())
 		p := tea.NewProgram(m, tea.WithAltScreen())
 
 		if _, err := p.Run(); err != nil {
@@ -40,6 +39,26 @@ After stopping, you'll be prompted for a rating, interruptions, reflection and n
 	},
 }
 
+// sessionCoach returns the coach configured with coach_model, or nil if none
+// is configured or it was turned off.
+func sessionCoach() *coach.Coach {
+	model := viper.GetString("coach_model")
+	if model == "" || noCoachFlag {
+		return nil
+	}
+
+	return &coach.Coach{
+		Provider: coach.OpenAI{
+			BaseURL: viper.GetString("coach_base_url"),
+			Model:   model,
+			APIKey:  viper.GetString("coach_api_key"),
+		},
+		Timeout: viper.GetDuration("coach_timeout"),
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(startCmd)
+
+	startCmd.Flags().BoolVar(&noCoachFlag, "no-coach", false, "Start without a tip from the coach")
 }
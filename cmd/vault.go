@@ -0,0 +1,152 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"altum/internal/obsidian"
+)
+
+const vaultSearchDepth = 3
+
+func vaultSearchPaths() []string {
+	var roots []string
+	for _, root := range strings.Split(viper.GetString("vault_search_paths"), ",") {
+		root = strings.TrimSpace(root)
+		if root == "" {
+			continue
+		}
+		roots = append(roots, expandHome(root))
+	}
+
+	if len(roots) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		roots = []string{
+			filepath.Join(home, "Documents"),
+			filepath.Join(home, "Obsidian"),
+			filepath.Join(home, "Library", "Mobile Documents", "iCloud~md~obsidian", "Documents"),
+		}
+	}
+
+	return roots
+}
+
+// detectVault finds the vault containing start, or searches for one. It talks
+// to the user on stderr, so that output piped elsewhere stays clean, and only
+// asks which of several vaults to use when stdin is a terminal.
+func detectVault(cmd *cobra.Command, start string) (obsidian.Vault, bool, error) {
+	if path, ok := obsidian.FindVaultUp(start); ok {
+		vault, err := obsidian.LoadVault(path)
+		return vault, err == nil, err
+	}
+
+	paths := obsidian.SearchVaults(vaultSearchPaths(), vaultSearchDepth)
+	switch len(paths) {
+	case 0:
+		return obsidian.Vault{}, false, nil
+	case 1:
+		vault, err := obsidian.LoadVault(paths[0])
+		return vault, err == nil, err
+	}
+
+	w := cmd.ErrOrStderr()
+	fmt.Fprintln(w, "Found several Obsidian vaults:")
+	for i, path := range paths {
+		fmt.Fprintf(w, "  %d) %s\n", i+1, path)
+	}
+	if !interactive() {
+		fmt.Fprintln(w, "Run altum config detect PATH to choose one.")
+		return obsidian.Vault{}, false, nil
+	}
+	answer := prompt(w, fmt.Sprintf("Which vault should Altum use? [1-%d] ", len(paths)))
+	choice, err := strconv.Atoi(answer)
+	if err != nil || choice < 1 || choice > len(paths) {
+		return obsidian.Vault{}, false, nil
+	}
+
+	vault, err := obsidian.LoadVault(paths[choice-1])
+	return vault, err == nil, err
+}
+
+// offerVaultSettings shows the settings detected in vault and saves them, if
+// confirmed or yes is set. Without a terminal to ask on, they are only saved
+// with yes.
+func offerVaultSettings(cmd *cobra.Command, vault obsidian.Vault, yes bool) (bool, error) {
+	values := map[string]string{
+		"daily_notes_folder_path": vault.FolderPath(),
+		"date_format":             vault.DailyNotes.Format,
+	}
+	if templatePath := vault.TemplatePath(); templatePath != "" {
+		values["daily_note_template"] = templatePath
+	}
+	if weekly := vault.WeeklyNotes; weekly != nil {
+		values["weekly_notes_folder_path"] = vault.NotesFolderPath(weekly.Folder)
+		values["weekly_note_format"] = weekly.Format
+	}
+	if monthly := vault.MonthlyNotes; monthly != nil {
+		values["monthly_notes_folder_path"] = vault.NotesFolderPath(monthly.Folder)
+		values["monthly_note_format"] = monthly.Format
+	}
+
+	w := cmd.ErrOrStderr()
+	fmt.Fprintf(w, "Found Obsidian vault: %s (%s settings)\n", vault.Path, vault.DailyNotes.Source)
+	for _, key := range configKeys {
+		if value, ok := values[key]; ok {
+			fmt.Fprintf(w, "  %s: %s\n", key, value)
+		}
+	}
+
+	if !yes && !interactive() {
+		fmt.Fprintln(w, "Run altum config detect --yes to save these settings.")
+		return false, nil
+	}
+	if !yes {
+		answer := strings.ToLower(prompt(w, "Save these settings to Altum's config? [Y/n] "))
+		if answer != "" && answer != "y" && answer != "yes" {
+			return false, nil
+		}
+	}
+
+	configFile, err := saveConfigValues(values)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Fprintf(w, "Configuration saved to: %s\n", configFile)
+	return true, nil
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
+
+// interactive reports whether stdin is a terminal the user can answer on.
+func interactive() bool {
+	return term.IsTerminal(os.Stdin.Fd())
+}
+
+func prompt(w io.Writer, question string) string {
+	fmt.Fprint(w, question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimSpace(answer)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package coach
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"altum/internal/notes"
+	"altum/internal/report"
+)
+
+const (
+	// RecentSessions is how many of the latest sessions the coach is shown.
+	RecentSessions = 10
+	// recentDays is how far back recent sessions are looked for.
+	recentDays = 60
+	// DefaultTimeout is how long to wait for a tip before falling back.
+	DefaultTimeout = 8 * time.Second
+	// maxTipLength keeps a rambling reply to a short paragraph.
+	maxTipLength = 400
+)
+
+// ErrNoSessions is returned when there are no sessions to base a tip on.
+var ErrNoSessions = errors.New("no recent sessions")
+
+// Message is a chat message sent to a provider.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Provider answers a conversation with a chat model.
+type Provider interface {
+	Complete(ctx context.Context, messages []Message) (string, error)
+}
+
+// Coach gives a tip before a session, based on the ones before it.
+type Coach struct {
+	Provider Provider
+	Timeout  time.Duration
+}
+
+func (c Coach) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultTimeout
+	}
+	return c.Timeout
+}
+
+// Tip asks the provider for a tip on the session starting at now, based on
+// recent sessions, oldest first. It gives up after the coach's timeout.
+func (c Coach) Tip(ctx context.Context, sessions []notes.Session, now time.Time) (string, error) {
+	if len(sessions) == 0 {
+		return "", ErrNoSessions
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout())
+	defer cancel()
+
+	tip, err := c.Provider.Complete(ctx, Prompt(sessions, now))
+	if err != nil {
+		return "", err
+	}
+
+	tip = strings.Join(strings.Fields(tip), " ")
+	if tip == "" {
+		return "", errors.New("the coach gave an empty reply")
+	}
+	if runes := []rune(tip); len(runes) > maxTipLength {
+		tip = strings.TrimSpace(string(runes[:maxTipLength-1])) + "…"
+	}
+	return tip, nil
+}
+
+// LoadRecentSessions returns up to RecentSessions of the latest sessions,
+// oldest first, with sessions split at midnight joined back together.
+func LoadRecentSessions(c notes.Config) ([]notes.Session, error) {
+	stats, _, err := report.Load(c, report.LastDays(c.Today(), recentDays), false)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := stats.LoggedSessions()
+	if len(sessions) > RecentSessions {
+		sessions = sessions[len(sessions)-RecentSessions:]
+	}
+	return sessions, nil
+}
+
+const systemPrompt = `You are a deep work coach in the spirit of Cal Newport's Deep Work. You review a
+person's recent deep work sessions and give one specific, practical tip for the session they are about
+to start: how long to go for, when to work, how to handle the distractions they keep noting, or how to
+build on their reflections. Refer to their actual sessions. Reply with the tip only, in at most two
+sentences, without a greeting or preamble.`
+
+// Prompt builds the conversation asking for a tip, describing each session
+// on a line.
+func Prompt(sessions []notes.Session, now time.Time) []Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "My last %d deep work sessions, oldest first:\n", len(sessions))
+	for _, session := range sessions {
+		b.WriteString("- " + describe(session) + "\n")
+	}
+	fmt.Fprintf(&b, "\nI'm starting a session now, on %s at %s. What should I keep in mind?",
+		now.Format("Monday"), now.Format("15:04"))
+
+	return []Message{
+		{Role: "system", Content: strings.Join(strings.Fields(systemPrompt), " ")},
+		{Role: "user", Content: b.String()},
+	}
+}
+
+func describe(session notes.Session) string {
+	when := session.Date.Format("Mon Jan 2")
+	if !session.Start.IsZero() {
+		when += " at " + session.Start.Format("15:04")
+	}
+
+	parts := []string{when, fmt.Sprintf("%d min", int(session.Duration.Minutes()))}
+	if session.FocusQuality > 0 {
+		parts = append(parts, fmt.Sprintf("focus %d/5", session.FocusQuality))
+	}
+	for _, field := range []struct{ name, value string }{
+		{"milestone", session.Milestone},
+		{"interruptions", session.Interruptions},
+		{"reflection", session.Reflection},
+	} {
+		if value := strings.TrimSpace(field.value); value != "" {
+			parts = append(parts, fmt.Sprintf("%s: %s", field.name, value))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// fallbackTips are shown when the coach isn't configured or can't be reached.
+var fallbackTips = []string{
+	"Decide what done looks like for this session before you start, and write it down.",
+	"Put your phone in another room; distance beats willpower.",
+	"Close every tab and app you don't need for this session.",
+	"Work in one uninterrupted block. If a thought pulls you away, jot it down and keep going.",
+	"Schedule your next break now so you don't have to think about it until it comes.",
+	"Embrace boredom: when the urge to switch tasks comes, wait it out for a minute.",
+	"Shut down your inbox and chat until the session is over.",
+}
+
+// FallbackTip returns a general deep work tip, a different one each day.
+func FallbackTip(now time.Time) string {
+	return fallbackTips[now.YearDay()%len(fallbackTips)]
+}
@@ -0,0 +1,113 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package coach
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL is the OpenAI API. Local servers such as Ollama
+// (http://localhost:11434/v1) or llama.cpp (http://localhost:8080/v1) serve
+// the same API.
+const DefaultBaseURL = "https://api.openai.com/v1"
+
+// maxTipTokens limits the length of the reply.
+const maxTipTokens = 200
+
+// OpenAI is a Provider for any server with an OpenAI-compatible chat
+// completions endpoint.
+type OpenAI struct {
+	BaseURL string
+	Model   string
+	// APIKey is sent as a bearer token when set. Local servers usually
+	// don't need one.
+	APIKey string
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+type chatRequest struct {
+	Model     string    `json:"model"`
+	Messages  []Message `json:"messages"`
+	MaxTokens int       `json:"max_tokens,omitempty"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message Message `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Complete sends messages to the chat completions endpoint and returns the
+// first choice.
+func (o OpenAI) Complete(ctx context.Context, messages []Message) (string, error) {
+	body, err := json.Marshal(chatRequest{Model: o.Model, Messages: messages, MaxTokens: maxTipTokens})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.endpoint(), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.APIKey)
+	}
+
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		// Leave out the method and URL, which only clutter the message.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+
+	var chat chatResponse
+	decodeErr := json.Unmarshal(data, &chat)
+	if resp.StatusCode != http.StatusOK {
+		if decodeErr == nil && chat.Error != nil && chat.Error.Message != "" {
+			return "", fmt.Errorf("%s: %s", resp.Status, chat.Error.Message)
+		}
+		return "", fmt.Errorf("%s", resp.Status)
+	}
+	if decodeErr != nil {
+		return "", fmt.Errorf("unexpected response: %w", decodeErr)
+	}
+	if len(chat.Choices) == 0 {
+		return "", fmt.Errorf("the response has no choices")
+	}
+	return chat.Choices[0].Message.Content, nil
+}
+
+func (o OpenAI) endpoint() string {
+	baseURL := o.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return strings.TrimRight(baseURL, "/") + "/chat/completions"
+}
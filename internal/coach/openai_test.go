@@ -0,0 +1,155 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package coach
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"altum/internal/notes"
+)
+
+var testSessions = []notes.Session{
+	{
+		Date:          time.Date(2025, time.November, 14, 0, 0, 0, 0, time.UTC),
+		Start:         time.Date(2025, time.November, 14, 9, 0, 0, 0, time.UTC),
+		Duration:      90 * time.Minute,
+		FocusQuality:  4,
+		Milestone:     "Wrote the parser",
+		Interruptions: "Slack pings",
+	},
+	{
+		Date:       time.Date(2025, time.November, 15, 0, 0, 0, 0, time.UTC),
+		Duration:   45 * time.Minute,
+		Milestone:  "Fixed tests",
+		Reflection: "Phone away helped",
+	},
+}
+
+var testNow = time.Date(2025, time.November, 17, 14, 5, 0, 0, time.UTC)
+
+// chatServer stands in for a chat completions endpoint, checking each
+// request and replying with reply.
+func chatServer(t *testing.T, status int, reply string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("request = %s %s, want POST /v1/chat/completions", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer secret")
+		}
+
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		if req.Model != "test-model" {
+			t.Errorf("model = %q, want %q", req.Model, "test-model")
+		}
+		if len(req.Messages) != 2 || req.Messages[0].Role != "system" || req.Messages[1].Role != "user" {
+			t.Errorf("messages = %+v, want a system and a user message", req.Messages)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(reply))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testCoach(server *httptest.Server) Coach {
+	return Coach{
+		Provider: OpenAI{BaseURL: server.URL + "/v1/", Model: "test-model", APIKey: "secret", Client: server.Client()},
+		Timeout:  time.Second,
+	}
+}
+
+func TestTip(t *testing.T) {
+	server := chatServer(t, http.StatusOK, `{"choices":[{"message":{"role":"assistant","content":"  Silence Slack\nbefore you start.  "}}]}`)
+
+	tip, err := testCoach(server).Tip(context.Background(), testSessions, testNow)
+	if err != nil {
+		t.Fatalf("Tip() error = %v", err)
+	}
+	if want := "Silence Slack before you start."; tip != want {
+		t.Errorf("Tip() = %q, want %q", tip, want)
+	}
+}
+
+func TestTipError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		reply  string
+		want   string
+	}{
+		{"api error", http.StatusUnauthorized, `{"error":{"message":"Incorrect API key provided"}}`, "401 Unauthorized: Incorrect API key provided"},
+		{"not json", http.StatusBadGateway, `<html>Bad Gateway</html>`, "502 Bad Gateway"},
+		{"no choices", http.StatusOK, `{"choices":[]}`, "the response has no choices"},
+		{"empty reply", http.StatusOK, `{"choices":[{"message":{"content":" "}}]}`, "the coach gave an empty reply"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := chatServer(t, tt.status, tt.reply)
+
+			_, err := testCoach(server).Tip(context.Background(), testSessions, testNow)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Tip() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTipTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server only notices the client giving up once the body is read.
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	c := Coach{Provider: OpenAI{BaseURL: server.URL, Model: "test-model"}, Timeout: 50 * time.Millisecond}
+	start := time.Now()
+	_, err := c.Tip(context.Background(), testSessions, testNow)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Tip() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Tip() took %v, want it to give up after the timeout", elapsed)
+	}
+}
+
+func TestTipWithoutSessions(t *testing.T) {
+	c := Coach{Provider: OpenAI{BaseURL: "http://127.0.0.1:0"}}
+	if _, err := c.Tip(context.Background(), nil, testNow); !errors.Is(err, ErrNoSessions) {
+		t.Errorf("Tip() error = %v, want %v", err, ErrNoSessions)
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	messages := Prompt(testSessions, testNow)
+	user := messages[1].Content
+
+	for _, want := range []string{
+		"My last 2 deep work sessions",
+		"- Fri Nov 14 at 09:00, 90 min, focus 4/5, milestone: Wrote the parser, interruptions: Slack pings\n",
+		"- Sat Nov 15, 45 min, milestone: Fixed tests, reflection: Phone away helped\n",
+		"on Monday at 14:05",
+	} {
+		if !strings.Contains(user, want) {
+			t.Errorf("Prompt() user message missing %q:\n%s", want, user)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	FolderPath   string
+	DateFormat   DateFormat
+	TemplatePath string
+	Section      Section
+	Format       *EntryFormat
+
+	MidnightPolicy string
+	DayStartsAt    time.Duration
+	Location       *time.Location
+
+	// CacheDir holds the index of parsed notes. Empty means the user cache
+	// directory.
+	CacheDir string
+}
+
+func (c Config) format() *EntryFormat {
+	if c.Format == nil {
+		return defaultFormat
+	}
+	return c.Format
+}
+
+func (c Config) formats() []*EntryFormat {
+	if c.format() == defaultFormat {
+		return []*EntryFormat{defaultFormat}
+	}
+	return []*EntryFormat{c.format(), defaultFormat}
+}
+
+// NoteName returns the note's path relative to the daily notes folder,
+// without the .md extension. Formats may contain "/" to nest notes in
+// folders, e.g. YYYY/MM/YYYY-MM-DD.
+func (c Config) NoteName(date time.Time) string {
+	return c.DateFormat.Format(date)
+}
+
+// NoteTitle returns the note's file name without folders or extension, which
+// is how Obsidian titles and links it.
+func (c Config) NoteTitle(date time.Time) string {
+	return path.Base(c.NoteName(date))
+}
+
+func (c Config) ParseNoteName(name string) (time.Time, error) {
+	return c.DateFormat.Parse(name)
+}
+
+func (c Config) NotePath(date time.Time) string {
+	return filepath.Join(c.FolderPath, filepath.FromSlash(c.NoteName(date))+".md")
+}
+
+// ResolveTemplatePath expands a leading ~ and resolves relative template
+// paths against the daily notes folder. Obsidian omits the .md extension, so
+// it is added when missing.
+func (c Config) ResolveTemplatePath() string {
+	path := c.TemplatePath
+	if path == "" {
+		return ""
+	}
+
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(c.FolderPath, path)
+	}
+	if filepath.Ext(path) == "" {
+		path += ".md"
+	}
+
+	return path
+}
@@ -0,0 +1,224 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultDateFormat = "YYYY-MM-DD"
+
+// DateFormat is a daily note name format, given either as a moment.js format
+// (as used by Obsidian) or as a Go reference layout.
+type DateFormat struct {
+	format   string
+	goLayout string
+	tokens   []momentToken
+	pattern  *regexp.Regexp
+}
+
+func ParseDateFormat(format string) DateFormat {
+	if format == "" {
+		format = DefaultDateFormat
+	}
+
+	f := DateFormat{format: format}
+	if IsGoLayout(format) {
+		f.goLayout = format
+		return f
+	}
+
+	f.tokens = tokenizeMoment(format)
+	if layout, ok := MomentToGoLayout(format); ok {
+		f.goLayout = layout
+		return f
+	}
+
+	var pattern strings.Builder
+	pattern.WriteString("(?i)^")
+	for _, tok := range f.tokens {
+		if tok.token == "" {
+			pattern.WriteString(regexp.QuoteMeta(tok.literal))
+			continue
+		}
+		pattern.WriteString("(" + momentTokenPattern(tok.token) + ")")
+	}
+	pattern.WriteString("$")
+	f.pattern = regexp.MustCompile(pattern.String())
+
+	return f
+}
+
+// goReferenceTokens are the numeric parts of Go's reference time that a
+// layout can be built from.
+var goReferenceTokens = []string{"2006", "002", "_2", "01", "02", "03", "04", "05", "06", "15"}
+
+// IsGoLayout reports whether format is a Go reference layout rather than a
+// moment.js format. Go layouts always contain reference numbers such as 2006
+// or 01, while a moment.js format only has digits in [escaped] text or as
+// stray literals that are not part of the reference time.
+func IsGoLayout(format string) bool {
+	var unescaped strings.Builder
+	inLiteral := false
+	for _, r := range format {
+		switch {
+		case r == '[':
+			inLiteral = true
+		case r == ']':
+			inLiteral = false
+		case !inLiteral:
+			unescaped.WriteRune(r)
+		}
+	}
+
+	for _, token := range goReferenceTokens {
+		if strings.Contains(unescaped.String(), token) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f DateFormat) String() string {
+	return f.format
+}
+
+func (f DateFormat) Format(t time.Time) string {
+	if f.goLayout != "" {
+		return t.Format(f.goLayout)
+	}
+	return FormatMoment(t, f.format)
+}
+
+func (f DateFormat) Parse(value string) (time.Time, error) {
+	if f.goLayout != "" {
+		return time.Parse(f.goLayout, value)
+	}
+
+	matches := f.pattern.FindStringSubmatch(value)
+	if matches == nil {
+		return time.Time{}, fmt.Errorf("%q does not match date format %q", value, f.format)
+	}
+
+	var (
+		year, month, day, yearDay = -1, -1, -1, -1
+		isoYear, isoWeek, weekday = -1, -1, -1
+		localeYear, localeWeek    = -1, -1
+	)
+
+	group := 1
+	for _, tok := range f.tokens {
+		if tok.token == "" {
+			continue
+		}
+		text := matches[group]
+		group++
+
+		switch tok.token {
+		case "YYYY":
+			year, _ = strconv.Atoi(text)
+		case "YY":
+			yy, _ := strconv.Atoi(text)
+			year = 2000 + yy
+			if yy >= 69 {
+				year = 1900 + yy
+			}
+		case "GGGG":
+			isoYear, _ = strconv.Atoi(text)
+		case "gggg":
+			localeYear, _ = strconv.Atoi(text)
+		case "ww", "w", "wo":
+			localeWeek, _ = strconv.Atoi(strings.TrimRight(text, "stndrh"))
+		case "MMMM", "MMM":
+			month = monthByName(text)
+		case "MM", "M", "Mo":
+			month, _ = strconv.Atoi(strings.TrimRight(text, "stndrh"))
+		case "DDDD", "DDD":
+			yearDay, _ = strconv.Atoi(text)
+		case "DD", "D", "Do":
+			day, _ = strconv.Atoi(strings.TrimRight(text, "stndrh"))
+		case "WW", "W", "Wo":
+			isoWeek, _ = strconv.Atoi(strings.TrimRight(text, "stndrh"))
+		case "E":
+			weekday, _ = strconv.Atoi(text)
+		}
+	}
+
+	var date time.Time
+	switch {
+	case year >= 0 && month > 0 && day > 0:
+		date = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	case year >= 0 && yearDay > 0:
+		date = time.Date(year, time.January, yearDay, 0, 0, 0, 0, time.UTC)
+	case isoWeek > 0 && (isoYear >= 0 || year >= 0):
+		if isoYear < 0 {
+			isoYear = year
+		}
+		if weekday < 1 {
+			weekday = 1
+		}
+		jan4 := time.Date(isoYear, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		date = monday.AddDate(0, 0, (isoWeek-1)*7+weekday-1)
+	case localeWeek > 0 && (localeYear >= 0 || year >= 0):
+		if localeYear < 0 {
+			localeYear = year
+		}
+		if weekday < 1 {
+			weekday = 1
+		}
+		date = localeWeekStart(localeYear, time.UTC).AddDate(0, 0, (localeWeek-1)*7+weekday-1)
+	case year >= 0 && month > 0:
+		date = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Time{}, fmt.Errorf("date format %q does not identify a single day", f.format)
+	}
+
+	if !strings.EqualFold(f.Format(date), value) {
+		return time.Time{}, fmt.Errorf("%q is not a valid date for format %q", value, f.format)
+	}
+
+	return date, nil
+}
+
+func momentTokenPattern(token string) string {
+	switch token {
+	case "YYYY", "GGGG", "gggg":
+		return `\d{4}`
+	case "MMMM", "dddd":
+		return `[A-Za-z]+`
+	case "MMM", "ddd":
+		return `[A-Za-z]{3}`
+	case "dd":
+		return `[A-Za-z]{2}`
+	case "DDDD":
+		return `\d{3}`
+	case "YY", "MM", "DD", "HH", "hh", "mm", "ss", "WW", "ww":
+		return `\d{2}`
+	case "Mo", "Do", "Wo", "wo":
+		return `\d{1,2}(?:st|nd|rd|th)`
+	case "A", "a":
+		return `[AaPp][Mm]`
+	case "ZZ":
+		return `[+-]\d{4}`
+	case "Z":
+		return `[+-]\d{2}:\d{2}`
+	case "X", "x":
+		return `\d+`
+	}
+	return `\d{1,3}`
+}
+
+func monthByName(name string) int {
+	for month := time.January; month <= time.December; month++ {
+		if strings.EqualFold(month.String(), name) || strings.EqualFold(month.String()[:3], name) {
+			return int(month)
+		}
+	}
+	return -1
+}
@@ -0,0 +1,160 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"testing"
+	"time"
+)
+
+var testDate = time.Date(2025, time.November, 15, 0, 0, 0, 0, time.UTC)
+
+func TestDateFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"YYYY-MM-DD", "2025-11-15"},
+		{"dddd, MMMM Do YYYY", "Saturday, November 15th 2025"},
+		{"ddd MMM D YYYY", "Sat Nov 15 2025"},
+		{"[Daily] YYYY-MM-DD", "Daily 2025-11-15"},
+		{"YYYY/MM/YYYY-MM-DD", "2025/11/2025-11-15"},
+		{"DD.MM.YY", "15.11.25"},
+		{"D MMMM YYYY", "15 November 2025"},
+		{"Do MMM YYYY", "15th Nov 2025"},
+		{"YYYY-DDDD", "2025-319"},
+		{"GGGG-[W]WW-E", "2025-W46-6"},
+		{"2006-01-02", "2025-11-15"},
+		{"02 Jan 2006", "15 Nov 2025"},
+	}
+
+	// A spread of dates, including ones where the ISO week year differs
+	// from the calendar year.
+	dates := []time.Time{
+		testDate,
+		time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.June, 22, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			f := ParseDateFormat(tt.format)
+			if got := f.Format(testDate); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+
+			for _, date := range dates {
+				name := f.Format(date)
+				got, err := f.Parse(name)
+				if err != nil {
+					t.Errorf("Parse(%q) error = %v", name, err)
+					continue
+				}
+				if !got.Equal(date) {
+					t.Errorf("Parse(%q) = %s, want %s", name, got.Format(time.DateOnly), date.Format(time.DateOnly))
+				}
+			}
+		})
+	}
+}
+
+func TestDateFormatParseInvalid(t *testing.T) {
+	tests := []struct {
+		format string
+		value  string
+	}{
+		{"YYYY-MM-DD", "2025-11-31"},
+		{"YYYY-MM-DD", "Meeting notes"},
+		{"dddd, MMMM Do YYYY", "Friday, November 15th 2025"},
+		{"Do MMM YYYY", "15nd Nov 2025"},
+	}
+
+	for _, tt := range tests {
+		if got, err := ParseDateFormat(tt.format).Parse(tt.value); err == nil {
+			t.Errorf("Parse(%q) with %q = %s, want an error", tt.value, tt.format, got.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestDateFormatWeeks(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		date   time.Time
+		locale string
+		iso    string
+	}{
+		{day(2020, time.December, 28), "2021-W01", "2020-W53"},
+		{day(2021, time.January, 3), "2021-W01", "2020-W53"},
+		{day(2021, time.January, 4), "2021-W02", "2021-W01"},
+		{day(2022, time.December, 31), "2023-W01", "2022-W52"},
+		{day(2023, time.January, 1), "2023-W01", "2022-W52"},
+		{day(2023, time.January, 2), "2023-W02", "2023-W01"},
+		{day(2024, time.December, 29), "2024-W52", "2024-W52"},
+		{day(2024, time.December, 31), "2025-W01", "2025-W01"},
+		{day(2025, time.December, 29), "2026-W01", "2026-W01"},
+		{day(2026, time.December, 27), "2026-W52", "2026-W52"},
+		{day(2026, time.December, 28), "2027-W01", "2026-W53"},
+		{day(2027, time.January, 1), "2027-W01", "2026-W53"},
+		{day(2027, time.January, 4), "2027-W02", "2027-W01"},
+	}
+
+	locale := ParseDateFormat("gggg-[W]ww")
+	iso := ParseDateFormat("GGGG-[W]WW")
+	for _, tt := range tests {
+		if got := locale.Format(tt.date); got != tt.locale {
+			t.Errorf("Format(%s) with gggg-[W]ww = %q, want %q", tt.date.Format(time.DateOnly), got, tt.locale)
+		}
+		if got := iso.Format(tt.date); got != tt.iso {
+			t.Errorf("Format(%s) with GGGG-[W]WW = %q, want %q", tt.date.Format(time.DateOnly), got, tt.iso)
+		}
+	}
+
+	// Every day from December 28th to January 4th parses back to the Monday
+	// of its week, in both numberings.
+	for year := 2019; year <= 2030; year++ {
+		for date := day(year, time.December, 28); !date.After(day(year+1, time.January, 4)); date = date.AddDate(0, 0, 1) {
+			monday := date.AddDate(0, 0, -mondayOffset(date))
+			for _, f := range []DateFormat{locale, iso} {
+				name := f.Format(date)
+				got, err := f.Parse(name)
+				if err != nil {
+					t.Errorf("Parse(%q) error = %v", name, err)
+					continue
+				}
+				if !got.Equal(monday) {
+					t.Errorf("Parse(%q) = %s, want %s", name, got.Format(time.DateOnly), monday.Format(time.DateOnly))
+				}
+			}
+		}
+	}
+}
+
+func TestIsGoLayout(t *testing.T) {
+	tests := []struct {
+		format string
+		want   bool
+	}{
+		{"2006-01-02", true},
+		{"02 Jan 2006", true},
+		{"Monday, January 2 2006", true},
+		{"[Daily] 2006-01-02", true},
+		{"YYYY-MM-DD", false},
+		{"[Q1] YYYY-MM-DD", false},
+		{"[2006] YYYY-MM-DD", false},
+		{"gggg-[W]ww", false},
+		{"YYYY-MM-DD v2", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsGoLayout(tt.format); got != tt.want {
+			t.Errorf("IsGoLayout(%q) = %v, want %v", tt.format, got, tt.want)
+		}
+	}
+}
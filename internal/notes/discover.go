@@ -0,0 +1,63 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type NoteFile struct {
+	Path string
+	Date time.Time
+}
+
+// DailyNoteFiles walks the daily notes folder, including nested folders, and
+// returns every note whose relative path matches the date format.
+func (c Config) DailyNoteFiles() ([]NoteFile, error) {
+	var files []NoteFile
+
+	err := filepath.WalkDir(c.FolderPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != c.FolderPath && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+
+		if file, ok := c.noteFile(path); ok {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read daily notes directory: %w", err)
+	}
+
+	return files, nil
+}
+
+// noteFile returns the note at path if its name matches the date format.
+func (c Config) noteFile(path string) (NoteFile, bool) {
+	rel, err := filepath.Rel(c.FolderPath, path)
+	if err != nil {
+		return NoteFile{}, false
+	}
+	name := filepath.ToSlash(strings.TrimSuffix(rel, ".md"))
+
+	date, err := c.ParseNoteName(name)
+	if err != nil || c.NoteName(date) != name {
+		return NoteFile{}, false
+	}
+	return NoteFile{Path: path, Date: date}, true
+}
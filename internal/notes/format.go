@@ -0,0 +1,333 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	FormatBullet   = "bullet"
+	FormatCallout  = "callout"
+	FormatTable    = "table"
+	FormatDataview = "dataview"
+
+	headerTemplateName = "header"
+	fieldMarker        = "\x1f"
+
+	// continuedSuffix follows the number of an entry continuing a session
+	// split at the day boundary, so it isn't read back as another session.
+	continuedSuffix = " (continued)"
+)
+
+// Templates receive every value as a string so that the rendered entry can
+// be matched back to its fields when parsing. A template may also define a
+// "header" block, written once when the section is created; entries of such
+// formats are written without blank lines between them, as table rows are.
+var presetFormats = map[string]string{
+	FormatBullet: `#### Session {{.Number}}
+- Time: {{.Start}} - {{.End}}
+- Duration: {{.Minutes}} minutes {{.Seconds}} seconds
+- Milestone: {{.Milestone}}
+- Focus Quality: {{.FocusQuality}}/5
+{{if .Interruptions}}- Interruptions: {{.Interruptions}}
+{{end}}{{if .Reflection}}- Reflection: {{.Reflection}}
+{{end}}`,
+
+	FormatCallout: `> [!abstract] Session {{.Number}} · {{.Start}} - {{.End}}
+> - **Duration:** {{.Minutes}} minutes {{.Seconds}} seconds
+> - **Milestone:** {{.Milestone}}
+> - **Focus quality:** {{.FocusQuality}}/5
+{{if .Interruptions}}> - **Interruptions:** {{.Interruptions}}
+{{end}}{{if .Reflection}}> - **Reflection:** {{.Reflection}}
+{{end}}`,
+
+	FormatTable: `{{define "header"}}| # | Time | Duration | Focus | Milestone | Interruptions | Reflection |
+| --- | --- | --- | --- | --- | --- | --- |
+{{end}}| {{.Number}} | {{.Start}} - {{.End}} | {{.Minutes}}m {{.Seconds}}s | {{.FocusQuality}}/5 | {{.Milestone}} | {{.Interruptions}} | {{.Reflection}} |
+`,
+
+	FormatDataview: `#### Session {{.Number}}
+- start:: {{.Start}}
+- end:: {{.End}}
+- duration:: {{.Minutes}} minutes, {{.Seconds}} seconds
+- milestone:: {{.Milestone}}
+- focus_quality:: {{.FocusQuality}}
+{{if .Interruptions}}- interruptions:: {{.Interruptions}}
+{{end}}{{if .Reflection}}- reflection:: {{.Reflection}}
+{{end}}`,
+}
+
+var entryFields = []string{
+	"Number", "Date", "Start", "End", "Duration", "Minutes", "Seconds",
+	"Milestone", "FocusQuality", "Interruptions", "Reflection",
+}
+
+var fieldPatterns = map[string]string{
+	"Number":       `\d+(?: \(continued\))?`,
+	"Date":         `\d{4}-\d{2}-\d{2}`,
+	"Start":        `(?:\d{4}-\d{2}-\d{2} )?\d{1,2}:\d{2}(?::\d{2})?`,
+	"End":          `(?:\d{4}-\d{2}-\d{2} )?\d{1,2}:\d{2}(?::\d{2})?`,
+	"Duration":     `\d+ minutes \d+ seconds`,
+	"Minutes":      `\d+`,
+	"Seconds":      `\d+`,
+	"FocusQuality": `\d*`,
+}
+
+// Entry is a finished session as captured by the session TUI.
+type Entry struct {
+	Start         time.Time
+	End           time.Time
+	Duration      time.Duration
+	Milestone     string
+	FocusQuality  string
+	Interruptions string
+	Reflection    string
+	// Continued marks the part of a split session after the day boundary.
+	Continued bool
+}
+
+type entryData struct {
+	Number        string
+	Date          string
+	Start         string
+	End           string
+	Duration      string
+	Minutes       string
+	Seconds       string
+	Milestone     string
+	FocusQuality  string
+	Interruptions string
+	Reflection    string
+}
+
+type linePattern struct {
+	re     *regexp.Regexp
+	fields []string
+	start  bool
+}
+
+type EntryFormat struct {
+	name     string
+	tmpl     *template.Template
+	header   []string
+	patterns []linePattern
+}
+
+var defaultFormat = mustLoadPreset(FormatBullet)
+
+func PresetNames() []string {
+	return []string{FormatBullet, FormatCallout, FormatTable, FormatDataview}
+}
+
+func mustLoadPreset(name string) *EntryFormat {
+	format, err := LoadEntryFormat(name, "")
+	if err != nil {
+		panic(err)
+	}
+	return format
+}
+
+// LoadEntryFormat returns the entry format for a preset name, or the Go
+// text/template in templatePath if one is given.
+func LoadEntryFormat(name, templatePath string) (*EntryFormat, error) {
+	if templatePath != "" {
+		source, err := os.ReadFile(templatePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read entry template: %w", err)
+		}
+		return NewEntryFormat(templatePath, string(source))
+	}
+
+	if name == "" {
+		name = FormatBullet
+	}
+	source, ok := presetFormats[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown entry format %q (available: %s)", name, strings.Join(PresetNames(), ", "))
+	}
+	return NewEntryFormat(name, source)
+}
+
+func NewEntryFormat(name, source string) (*EntryFormat, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid entry template: %w", err)
+	}
+
+	format := &EntryFormat{name: name, tmpl: tmpl}
+
+	markers := entryData{}
+	for _, field := range entryFields {
+		setField(&markers, field, fieldMarker+field+fieldMarker)
+	}
+
+	if tmpl.Lookup(headerTemplateName) != nil {
+		var header strings.Builder
+		if err := tmpl.ExecuteTemplate(&header, headerTemplateName, markers); err != nil {
+			return nil, fmt.Errorf("invalid entry template header: %w", err)
+		}
+		format.header = nonEmptyLines(header.String())
+	}
+
+	var skeleton strings.Builder
+	if err := tmpl.Execute(&skeleton, markers); err != nil {
+		return nil, fmt.Errorf("invalid entry template: %w", err)
+	}
+
+	for _, line := range nonEmptyLines(skeleton.String()) {
+		format.patterns = append(format.patterns, compileLinePattern(line))
+	}
+	if len(format.patterns) == 0 {
+		return nil, fmt.Errorf("entry template %q renders nothing", name)
+	}
+
+	hasStart := slices.ContainsFunc(format.patterns, func(p linePattern) bool { return p.start })
+	if !hasStart {
+		format.patterns[0].start = true
+	}
+
+	return format, nil
+}
+
+func (f *EntryFormat) Name() string {
+	return f.name
+}
+
+func (f *EntryFormat) compact() bool {
+	return len(f.header) > 0
+}
+
+func (f *EntryFormat) render(number int, date time.Time, entry Entry) (string, error) {
+	minutes := int(entry.Duration.Minutes())
+	seconds := int(entry.Duration.Seconds()) % 60
+
+	timeLayout := "15:04:05"
+	if entry.Start.Format("2006-01-02") != entry.End.Format("2006-01-02") {
+		timeLayout = "2006-01-02 15:04:05"
+	}
+
+	escape := func(s string) string { return s }
+	if f.compact() {
+		escape = func(s string) string { return strings.ReplaceAll(s, "|", `\|`) }
+	}
+
+	numberText := strconv.Itoa(number)
+	if entry.Continued {
+		numberText += continuedSuffix
+	}
+
+	data := entryData{
+		Number:        numberText,
+		Date:          date.Format("2006-01-02"),
+		Start:         entry.Start.Format(timeLayout),
+		End:           entry.End.Format(timeLayout),
+		Duration:      fmt.Sprintf("%d minutes %d seconds", minutes, seconds),
+		Minutes:       strconv.Itoa(minutes),
+		Seconds:       strconv.Itoa(seconds),
+		Milestone:     escape(entry.Milestone),
+		FocusQuality:  entry.FocusQuality,
+		Interruptions: escape(entry.Interruptions),
+		Reflection:    escape(entry.Reflection),
+	}
+
+	var b strings.Builder
+	if err := f.tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// match reports whether line belongs to an entry of this format, and if so
+// whether it starts a new entry and which field values it holds.
+func (f *EntryFormat) match(line string) (bool, bool, map[string]string) {
+	for _, pattern := range f.patterns {
+		matches := pattern.re.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		values := make(map[string]string, len(pattern.fields))
+		for i, field := range pattern.fields {
+			values[field] = strings.ReplaceAll(strings.TrimSpace(matches[i+1]), `\|`, "|")
+		}
+		return true, pattern.start, values
+	}
+	return false, false, nil
+}
+
+func (f *EntryFormat) isStart(line string) bool {
+	ok, start, _ := f.match(line)
+	return ok && start
+}
+
+func compileLinePattern(line string) linePattern {
+	var pattern linePattern
+	var re strings.Builder
+	re.WriteString("^")
+
+	parts := strings.Split(line, fieldMarker)
+	for i, part := range parts {
+		if i%2 == 0 {
+			re.WriteString(regexp.QuoteMeta(part))
+			continue
+		}
+		fieldPattern, ok := fieldPatterns[part]
+		if !ok {
+			fieldPattern = `.*?`
+		}
+		re.WriteString("(" + fieldPattern + ")")
+		pattern.fields = append(pattern.fields, part)
+		if part == "Number" {
+			pattern.start = true
+		}
+	}
+
+	re.WriteString("$")
+	pattern.re = regexp.MustCompile(re.String())
+	return pattern
+}
+
+func setField(data *entryData, field, value string) {
+	switch field {
+	case "Number":
+		data.Number = value
+	case "Date":
+		data.Date = value
+	case "Start":
+		data.Start = value
+	case "End":
+		data.End = value
+	case "Duration":
+		data.Duration = value
+	case "Minutes":
+		data.Minutes = value
+	case "Seconds":
+		data.Seconds = value
+	case "Milestone":
+		data.Milestone = value
+	case "FocusQuality":
+		data.FocusQuality = value
+	case "Interruptions":
+		data.Interruptions = value
+	case "Reflection":
+		data.Reflection = value
+	}
+}
+
+func nonEmptyLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
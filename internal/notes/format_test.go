@@ -0,0 +1,95 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEntryFormatPresets(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return testDate.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	entries := []Entry{
+		{
+			Start:         at(9, 0),
+			End:           at(10, 30),
+			Duration:      85*time.Minute + 20*time.Second,
+			Milestone:     "Wrote the parser",
+			FocusQuality:  "4",
+			Interruptions: "Slack pings, phone",
+			Reflection:    "Phone away helped",
+		},
+		{
+			Start:     at(14, 5),
+			End:       at(14, 50),
+			Duration:  45 * time.Minute,
+			Milestone: "Fixed tests",
+		},
+		{
+			Start:        at(23, 30),
+			End:          at(23, 59),
+			Duration:     29 * time.Minute,
+			Milestone:    "Late review",
+			FocusQuality: "2",
+			Continued:    true,
+		},
+	}
+
+	for _, name := range PresetNames() {
+		t.Run(name, func(t *testing.T) {
+			format, err := LoadEntryFormat(name, "")
+			if err != nil {
+				t.Fatalf("LoadEntryFormat() error = %v", err)
+			}
+			c := Config{Format: format, Location: time.UTC}
+
+			content := "# 2025-11-15\n\nPlans for the day.\n"
+			for i, entry := range entries {
+				var number int
+				content, number, err = c.InsertSession(content, testDate, entry)
+				if err != nil {
+					t.Fatalf("InsertSession() error = %v", err)
+				}
+				if number != i+1 {
+					t.Errorf("InsertSession() number = %d, want %d", number, i+1)
+				}
+			}
+
+			sessions, err := c.ParseSessions(strings.NewReader(content), testDate)
+			if err != nil {
+				t.Fatalf("ParseSessions() error = %v", err)
+			}
+			if len(sessions) != len(entries) {
+				t.Fatalf("ParseSessions() found %d sessions, want %d in:\n%s", len(sessions), len(entries), content)
+			}
+
+			for i, entry := range entries {
+				got := sessions[i]
+				rating, _ := strconv.Atoi(entry.FocusQuality)
+				if !got.Start.Equal(entry.Start) || !got.End.Equal(entry.End) {
+					t.Errorf("session %d time = %s - %s, want %s - %s", i+1, got.Start, got.End, entry.Start, entry.End)
+				}
+				if got.Duration != entry.Duration {
+					t.Errorf("session %d duration = %v, want %v", i+1, got.Duration, entry.Duration)
+				}
+				if got.FocusQuality != rating {
+					t.Errorf("session %d focus quality = %d, want %d", i+1, got.FocusQuality, rating)
+				}
+				if got.Milestone != entry.Milestone || got.Interruptions != entry.Interruptions || got.Reflection != entry.Reflection {
+					t.Errorf("session %d notes = %q, %q, %q, want %q, %q, %q", i+1,
+						got.Milestone, got.Interruptions, got.Reflection,
+						entry.Milestone, entry.Interruptions, entry.Reflection)
+				}
+				if got.Continued != entry.Continued {
+					t.Errorf("session %d continued = %v, want %v", i+1, got.Continued, entry.Continued)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"slices"
+	"strings"
+)
+
+const frontmatterDelimiter = "---"
+
+type Property struct {
+	Key   string
+	Value string
+}
+
+// SplitFrontmatter separates a leading YAML frontmatter block from the rest of
+// the note. The returned lines exclude the delimiters.
+func SplitFrontmatter(content string) ([]string, string, bool) {
+	lines := strings.SplitAfter(content, "\n")
+	if len(lines) == 0 || strings.TrimRight(lines[0], "\r\n") != frontmatterDelimiter {
+		return nil, content, false
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], "\r\n") == frontmatterDelimiter {
+			var properties []string
+			for _, line := range lines[1:i] {
+				properties = append(properties, strings.TrimRight(line, "\r\n"))
+			}
+			return properties, strings.Join(lines[i+1:], ""), true
+		}
+	}
+
+	return nil, content, false
+}
+
+// SetProperties updates or adds top-level frontmatter properties, leaving every
+// other key in place. A frontmatter block is created if the note has none.
+func SetProperties(content string, props []Property) string {
+	existing, body, ok := SplitFrontmatter(content)
+	if !ok {
+		body = content
+	}
+
+	pending := make(map[string]string, len(props))
+	for _, prop := range props {
+		pending[prop.Key] = prop.Value
+	}
+
+	var lines []string
+	skipping := false
+	for _, line := range existing {
+		if skipping {
+			if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "- ") {
+				continue
+			}
+			skipping = false
+		}
+
+		key, _, isProperty := splitProperty(line)
+		if isProperty {
+			if value, owned := pending[key]; owned {
+				lines = append(lines, key+": "+value)
+				delete(pending, key)
+				skipping = true
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	for _, prop := range props {
+		if _, missing := pending[prop.Key]; missing {
+			lines = append(lines, prop.Key+": "+prop.Value)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(frontmatterDelimiter + "\n")
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(frontmatterDelimiter + "\n")
+	if !ok && body != "" && !strings.HasPrefix(body, "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString(body)
+
+	return b.String()
+}
+
+// RemoveProperties removes top-level frontmatter properties, along with any
+// nested values, leaving every other key in place.
+func RemoveProperties(content string, keys []string) string {
+	existing, body, ok := SplitFrontmatter(content)
+	if !ok {
+		return content
+	}
+
+	var lines []string
+	skipping := false
+	for _, line := range existing {
+		if skipping {
+			if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "- ") {
+				continue
+			}
+			skipping = false
+		}
+
+		if key, _, isProperty := splitProperty(line); isProperty && slices.Contains(keys, key) {
+			skipping = true
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	var b strings.Builder
+	b.WriteString(frontmatterDelimiter + "\n")
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(frontmatterDelimiter + "\n")
+	b.WriteString(body)
+
+	return b.String()
+}
+
+// frontmatterProperties returns the top-level scalar properties of a note's
+// frontmatter, or nil if it has none.
+func frontmatterProperties(content string) map[string]string {
+	lines, _, ok := SplitFrontmatter(content)
+	if !ok {
+		return nil
+	}
+
+	properties := make(map[string]string)
+	for _, line := range lines {
+		if key, value, ok := splitProperty(line); ok {
+			properties[key] = value
+		}
+	}
+	return properties
+}
+
+func splitProperty(line string) (string, string, bool) {
+	if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' || line[0] == '-' {
+		return "", "", false
+	}
+
+	key, value, found := strings.Cut(line, ":")
+	if !found {
+		return "", "", false
+	}
+
+	value = strings.TrimSpace(value)
+	value = strings.Trim(value, `"'`)
+	return strings.TrimSpace(key), value, true
+}
@@ -0,0 +1,291 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// indexVersion is bumped whenever what the index stores changes, so that
+// old indexes are rebuilt rather than misread.
+const indexVersion = 2
+
+// IndexedNote is a daily note with the sessions and frontmatter properties
+// read from it.
+type IndexedNote struct {
+	NoteFile
+	Sessions   []Session
+	Properties map[string]string
+}
+
+type index struct {
+	Version     int
+	Fingerprint string
+	Notes       map[string]indexEntry
+}
+
+// indexEntry is what the index keeps of a note, valid while the note's
+// modification time and size are unchanged.
+type indexEntry struct {
+	ModTime    time.Time
+	Size       int64
+	Sessions   []Session
+	Properties map[string]string
+}
+
+type scanJob struct {
+	file NoteFile
+	info fs.FileInfo
+	slot int
+}
+
+// ScanNotes reads the daily notes that include accepts. Notes unchanged since
+// the last scan are taken from an index cached on disk; the rest are parsed
+// concurrently and the index updated. Notes that fail to parse are skipped
+// and returned as warnings, as is a date format that matches none of the
+// notes in the folder.
+func (c Config) ScanNotes(include func(NoteFile) bool) ([]IndexedNote, []error, error) {
+	indexPath := c.indexPath()
+	cached := c.readIndex(indexPath)
+	updated := index{Version: indexVersion, Fingerprint: c.fingerprint(), Notes: make(map[string]indexEntry)}
+
+	var notes []IndexedNote
+	var jobs []scanJob
+	markdown, matched := 0, 0
+
+	err := filepath.WalkDir(c.FolderPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != c.FolderPath && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		markdown++
+
+		file, ok := c.noteFile(path)
+		if !ok {
+			return nil
+		}
+		matched++
+
+		// Notes outside the scan keep their entries, and are checked
+		// when a scan next includes them.
+		entry, fresh := cached.Notes[path]
+		if !include(file) {
+			if fresh {
+				updated.Notes[path] = entry
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		fresh = fresh && entry.ModTime.Equal(info.ModTime()) && entry.Size == info.Size()
+		if fresh {
+			updated.Notes[path] = entry
+		}
+
+		notes = append(notes, IndexedNote{NoteFile: file})
+		if fresh {
+			notes[len(notes)-1].Sessions = c.inLocation(entry.Sessions)
+			notes[len(notes)-1].Properties = entry.Properties
+			return nil
+		}
+		jobs = append(jobs, scanJob{file: file, info: info, slot: len(notes) - 1})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read daily notes directory: %w", err)
+	}
+
+	var warnings []error
+	if markdown > 0 && matched == 0 {
+		warnings = append(warnings, fmt.Errorf("none of the %d notes in %s are named with the date format %q", markdown, c.FolderPath, c.DateFormat))
+	}
+
+	failed := make([]error, len(notes))
+	c.parseNotes(jobs, func(job scanJob, sessions []Session, properties map[string]string, err error) {
+		if err != nil {
+			failed[job.slot] = fmt.Errorf("failed to parse %s: %w", job.file.Path, err)
+			return
+		}
+		notes[job.slot].Sessions = sessions
+		notes[job.slot].Properties = properties
+	})
+
+	parsed := notes[:0]
+	for i, note := range notes {
+		if failed[i] != nil {
+			warnings = append(warnings, failed[i])
+			continue
+		}
+		parsed = append(parsed, note)
+	}
+	for _, job := range jobs {
+		if failed[job.slot] == nil {
+			note := notes[job.slot]
+			updated.Notes[job.file.Path] = indexEntry{
+				ModTime:    job.info.ModTime(),
+				Size:       job.info.Size(),
+				Sessions:   note.Sessions,
+				Properties: note.Properties,
+			}
+		}
+	}
+
+	if len(jobs) > 0 || len(updated.Notes) != len(cached.Notes) {
+		// The index only saves work, so failing to write it isn't an error.
+		_ = writeIndex(indexPath, updated)
+	}
+
+	return parsed, warnings, nil
+}
+
+// parseNotes reads the notes of jobs with a bounded pool of workers, calling
+// done for each from a single goroutine at a time.
+func (c Config) parseNotes(jobs []scanJob, done func(scanJob, []Session, map[string]string, error)) {
+	workers := min(runtime.GOMAXPROCS(0), len(jobs))
+	queue := make(chan scanJob)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				sessions, properties, err := c.readNote(job.file)
+				mu.Lock()
+				done(job, sessions, properties, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// readNote reads a note once for both its sessions and its properties.
+func (c Config) readNote(file NoteFile) ([]Session, map[string]string, error) {
+	content, err := os.ReadFile(file.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sessions, err := c.ParseSessions(bytes.NewReader(content), file.Date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sessions, frontmatterProperties(string(content)), nil
+}
+
+// inLocation restores the configured time zone, which the index only keeps
+// as an offset, to cached start and end times.
+func (c Config) inLocation(sessions []Session) []Session {
+	for i := range sessions {
+		if !sessions[i].Start.IsZero() {
+			sessions[i].Start = sessions[i].Start.In(c.location())
+		}
+		if !sessions[i].End.IsZero() {
+			sessions[i].End = sessions[i].End.In(c.location())
+		}
+	}
+	return sessions
+}
+
+// fingerprint identifies the settings parsed sessions depend on. An index
+// written with other settings is discarded.
+func (c Config) fingerprint() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d\n%s\n%s\n%s\n%s\n%s\n", indexVersion, c.DateFormat, c.Section.Title(), c.DayStartsAt, c.location(), c.MidnightPolicy)
+	for _, format := range c.formats() {
+		for _, pattern := range format.patterns {
+			fmt.Fprintln(&b, pattern.re.String())
+		}
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(b.String())))
+}
+
+// indexPath keeps an index per daily notes folder in the user cache
+// directory, or in CacheDir if set.
+func (c Config) indexPath() string {
+	dir := c.CacheDir
+	if dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			cacheDir = os.TempDir()
+		}
+		dir = filepath.Join(cacheDir, "altum", "index")
+	}
+
+	folder, err := filepath.Abs(c.FolderPath)
+	if err != nil {
+		folder = c.FolderPath
+	}
+	return filepath.Join(dir, fmt.Sprintf("%x.gob", sha256.Sum256([]byte(folder))))
+}
+
+// readIndex returns the cached index, or an empty one if it is missing,
+// unreadable or was written with other settings.
+func (c Config) readIndex(path string) index {
+	empty := index{Notes: make(map[string]indexEntry)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return empty
+	}
+
+	var cached index
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&cached); err != nil {
+		return empty
+	}
+	if cached.Version != indexVersion || cached.Fingerprint != c.fingerprint() || cached.Notes == nil {
+		return empty
+	}
+	return cached
+}
+
+func writeIndex(path string, idx index) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(idx); err != nil {
+		return err
+	}
+	return writeAtomic(path, buf.Bytes(), 0644)
+}
+
+// ClearIndex removes the cached index of the daily notes folder.
+func (c Config) ClearIndex() error {
+	err := os.Remove(c.indexPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+//go:build !unix
+
+/*
+Copyright © 2025 Eden Phillips
+*/
+
+package notes
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+)
+
+// staleLockAge is how old a lock file must be to have been left behind by a
+// process that crashed while saving. A save holds the lock for moments, and
+// nothing waits for it longer than lockTimeout.
+const staleLockAge = lockTimeout
+
+// Without flock, an exclusively created lock file stands in for the lock. It
+// records when it was taken, so one left behind by a crash is broken rather
+// than locking the note for good.
+func lockNote(notePath string, timeout time.Duration) (func(), error) {
+	path, err := lockPath(notePath)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			file.WriteString(time.Now().UTC().Format(time.RFC3339Nano))
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+		if staleLock(path) {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, ErrLocked
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// staleLock reports whether the lock file at path was taken more than
+// staleLockAge ago, going by the time written in it or, if that can't be
+// read, its modification time.
+func staleLock(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+
+	taken := info.ModTime()
+	if data, err := os.ReadFile(path); err == nil {
+		if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data))); err == nil {
+			taken = t
+		}
+	}
+	return time.Since(taken) > staleLockAge
+}
@@ -0,0 +1,47 @@
+//go:build unix
+
+/*
+Copyright © 2025 Eden Phillips
+*/
+
+package notes
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+func lockNote(notePath string, timeout time.Duration) (func(), error) {
+	path, err := lockPath(notePath)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) || time.Now().After(deadline) {
+			file.Close()
+			if errors.Is(err, syscall.EWOULDBLOCK) {
+				return nil, ErrLocked
+			}
+			return nil, err
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return func() {
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		file.Close()
+	}, nil
+}
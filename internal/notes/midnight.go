@@ -0,0 +1,178 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	MidnightStart = "start"
+	MidnightEnd   = "end"
+	MidnightSplit = "split"
+)
+
+type LoggedEntry struct {
+	Path   string
+	Number int
+}
+
+// LogSession saves a finished session according to the midnight policy:
+// sessions that cross into a new day are logged in the note of the day they
+// started ("start", the default), the day they ended ("end"), or split at
+// the day boundary into an entry in each day's note ("split"). If saving a
+// part fails, the parts already saved are returned with the error; pass
+// their count as skip to save only the rest on retry.
+func (c Config) LogSession(entry Entry, skip int) ([]LoggedEntry, error) {
+	var parts []Entry
+	var days []time.Time
+
+	switch c.MidnightPolicy {
+	case MidnightSplit:
+		parts = c.splitEntry(entry)
+		for _, part := range parts {
+			days = append(days, c.day(part.Start))
+		}
+	case MidnightEnd:
+		parts = []Entry{entry}
+		days = []time.Time{c.day(entry.End)}
+	case MidnightStart, "":
+		parts = []Entry{entry}
+		days = []time.Time{c.day(entry.Start)}
+	default:
+		_, err := ParseMidnightPolicy(c.MidnightPolicy)
+		return nil, err
+	}
+
+	var logged []LoggedEntry
+	for i, part := range parts {
+		if i < skip {
+			continue
+		}
+		path, number, err := c.SaveSession(days[i], part)
+		if err != nil {
+			return logged, err
+		}
+		logged = append(logged, LoggedEntry{Path: path, Number: number})
+	}
+
+	return logged, nil
+}
+
+// SplitByDay divides a parsed session that crosses midnight into one piece
+// per day, apportioning its duration by the time spent on each day. Pieces
+// after the first are marked as continued so they aren't counted as separate
+// sessions.
+func (c Config) SplitByDay(session Session) []Session {
+	if session.Start.IsZero() || session.End.IsZero() || !session.End.After(session.Start) {
+		return []Session{session}
+	}
+
+	var pieces []Session
+	remaining := session.Duration
+	wallClock := session.End.Sub(session.Start)
+
+	for start := session.Start; start.Before(session.End); {
+		day := c.day(start)
+		end := c.nextDay(day)
+		if end.After(session.End) {
+			end = session.End
+		}
+
+		piece := session
+		piece.Date = day
+		piece.Start = start
+		piece.End = end
+		piece.Continued = session.Continued || len(pieces) > 0
+		piece.Duration = time.Duration(float64(session.Duration) * float64(end.Sub(start)) / float64(wallClock))
+		if !end.Before(session.End) {
+			piece.Duration = remaining
+		}
+		remaining -= piece.Duration
+
+		pieces = append(pieces, piece)
+		start = end
+	}
+
+	return pieces
+}
+
+// splitEntry divides an entry at each day boundary it crosses. Parts end a
+// second before the boundary so their times fit on one day, and parts after
+// the first are marked as continued.
+func (c Config) splitEntry(entry Entry) []Entry {
+	var parts []Entry
+	remaining := entry.Duration
+	wallClock := entry.End.Sub(entry.Start)
+
+	for start := entry.Start; ; {
+		end := c.nextDay(c.day(start))
+		if !end.Before(entry.End) || wallClock <= 0 {
+			part := entry
+			part.Start = start
+			part.Duration = remaining
+			part.Continued = len(parts) > 0
+			return append(parts, part)
+		}
+
+		part := entry
+		part.Start = start
+		part.End = end.Add(-time.Second)
+		part.Continued = len(parts) > 0
+		part.Duration = time.Duration(float64(entry.Duration) * float64(end.Sub(start)) / float64(wallClock))
+		remaining -= part.Duration
+
+		parts = append(parts, part)
+		start = end
+	}
+}
+
+// Today returns the logical day it is now.
+func (c Config) Today() time.Time {
+	return c.day(time.Now())
+}
+
+func (c Config) location() *time.Location {
+	if c.Location == nil {
+		return time.Local
+	}
+	return c.Location
+}
+
+// day returns the logical day t falls on, as midnight of that date in the
+// configured time zone. Times before DayStartsAt belong to the previous day.
+func (c Config) day(t time.Time) time.Time {
+	year, month, day := t.In(c.location()).Add(-c.DayStartsAt).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, c.location())
+}
+
+// nextDay returns the moment the logical day after day begins.
+func (c Config) nextDay(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, c.location()).Add(c.DayStartsAt)
+}
+
+// ParseMidnightPolicy checks a midnight_policy value, which defaults to
+// "start" when empty.
+func ParseMidnightPolicy(value string) (string, error) {
+	switch value {
+	case "":
+		return MidnightStart, nil
+	case MidnightStart, MidnightEnd, MidnightSplit:
+		return value, nil
+	}
+	return "", fmt.Errorf("unknown midnight_policy %q (use %s, %s or %s)", value, MidnightStart, MidnightEnd, MidnightSplit)
+}
+
+// ParseDayStart parses a day_starts_at value such as "04:00".
+func ParseDayStart(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid day_starts_at %q, expected HH:MM", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type momentToken struct {
+	token   string
+	literal string
+}
+
+// Longest tokens first so that e.g. "MMMM" wins over "MM".
+var momentTokens = []string{
+	"YYYY", "GGGG", "gggg", "MMMM", "dddd", "DDDD",
+	"MMM", "ddd", "DDD",
+	"YY", "MM", "Mo", "DD", "Do", "dd", "HH", "hh", "mm", "ss", "WW", "Wo", "ww", "wo", "ZZ",
+	"Q", "M", "D", "d", "E", "e", "H", "h", "m", "s", "A", "a", "W", "w", "X", "x", "Z",
+}
+
+func tokenizeMoment(format string) []momentToken {
+	var tokens []momentToken
+	var literal strings.Builder
+
+	flush := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, momentToken{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(format); {
+		if format[i] == '[' {
+			if end := strings.IndexByte(format[i:], ']'); end > 0 {
+				literal.WriteString(format[i+1 : i+end])
+				i += end + 1
+				continue
+			}
+		}
+
+		matched := ""
+		for _, token := range momentTokens {
+			if strings.HasPrefix(format[i:], token) {
+				matched = token
+				break
+			}
+		}
+
+		if matched == "" {
+			literal.WriteByte(format[i])
+			i++
+			continue
+		}
+
+		flush()
+		tokens = append(tokens, momentToken{token: matched})
+		i += len(matched)
+	}
+	flush()
+
+	return tokens
+}
+
+// FormatMoment formats t using moment.js format tokens, as used by Obsidian.
+func FormatMoment(t time.Time, format string) string {
+	var b strings.Builder
+	for _, tok := range tokenizeMoment(format) {
+		if tok.token == "" {
+			b.WriteString(tok.literal)
+			continue
+		}
+		b.WriteString(formatMomentToken(t, tok.token))
+	}
+	return b.String()
+}
+
+// localeWeekStart returns the Monday starting week 1 of year: the week that
+// contains January 1st.
+func localeWeekStart(year int, loc *time.Location) time.Time {
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	return jan1.AddDate(0, 0, -mondayOffset(jan1))
+}
+
+// mondayOffset is the number of days t is after the Monday of its week.
+func mondayOffset(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+// LocaleWeek returns the week-numbering year and week of t as moment.js
+// formats gggg and ww with Obsidian's default Monday start: weeks run Monday
+// to Sunday, and week 1 is the week containing January 1st. A week spanning
+// New Year belongs to the year it ends in.
+func LocaleWeek(t time.Time) (year, week int) {
+	monday := time.Date(t.Year(), t.Month(), t.Day()-mondayOffset(t), 0, 0, 0, 0, t.Location())
+	year = monday.AddDate(0, 0, 6).Year()
+	start := localeWeekStart(year, t.Location())
+	return year, int(monday.Sub(start).Hours()/24+0.5)/7 + 1
+}
+
+func formatMomentToken(t time.Time, token string) string {
+	isoYear, isoWeek := t.ISOWeek()
+	localeYear, localeWeek := LocaleWeek(t)
+
+	switch token {
+	case "YYYY":
+		return fmt.Sprintf("%04d", t.Year())
+	case "YY":
+		return fmt.Sprintf("%02d", t.Year()%100)
+	case "GGGG":
+		return fmt.Sprintf("%04d", isoYear)
+	case "gggg":
+		return fmt.Sprintf("%04d", localeYear)
+	case "Q":
+		return strconv.Itoa((int(t.Month())-1)/3 + 1)
+	case "MMMM":
+		return t.Month().String()
+	case "MMM":
+		return t.Month().String()[:3]
+	case "MM":
+		return fmt.Sprintf("%02d", int(t.Month()))
+	case "Mo":
+		return ordinal(int(t.Month()))
+	case "M":
+		return strconv.Itoa(int(t.Month()))
+	case "DDDD":
+		return fmt.Sprintf("%03d", t.YearDay())
+	case "DDD":
+		return strconv.Itoa(t.YearDay())
+	case "DD":
+		return fmt.Sprintf("%02d", t.Day())
+	case "Do":
+		return ordinal(t.Day())
+	case "D":
+		return strconv.Itoa(t.Day())
+	case "dddd":
+		return t.Weekday().String()
+	case "ddd":
+		return t.Weekday().String()[:3]
+	case "dd":
+		return t.Weekday().String()[:2]
+	case "d", "e":
+		return strconv.Itoa(int(t.Weekday()))
+	case "E":
+		if t.Weekday() == time.Sunday {
+			return "7"
+		}
+		return strconv.Itoa(int(t.Weekday()))
+	case "HH":
+		return fmt.Sprintf("%02d", t.Hour())
+	case "H":
+		return strconv.Itoa(t.Hour())
+	case "hh":
+		return fmt.Sprintf("%02d", twelveHour(t.Hour()))
+	case "h":
+		return strconv.Itoa(twelveHour(t.Hour()))
+	case "mm":
+		return fmt.Sprintf("%02d", t.Minute())
+	case "m":
+		return strconv.Itoa(t.Minute())
+	case "ss":
+		return fmt.Sprintf("%02d", t.Second())
+	case "s":
+		return strconv.Itoa(t.Second())
+	case "A":
+		return t.Format("PM")
+	case "a":
+		return t.Format("pm")
+	case "WW":
+		return fmt.Sprintf("%02d", isoWeek)
+	case "Wo":
+		return ordinal(isoWeek)
+	case "W":
+		return strconv.Itoa(isoWeek)
+	case "ww":
+		return fmt.Sprintf("%02d", localeWeek)
+	case "wo":
+		return ordinal(localeWeek)
+	case "w":
+		return strconv.Itoa(localeWeek)
+	case "X":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "x":
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	case "ZZ":
+		return t.Format("-0700")
+	case "Z":
+		return t.Format("-07:00")
+	}
+	return token
+}
+
+func twelveHour(hour int) int {
+	if hour%12 == 0 {
+		return 12
+	}
+	return hour % 12
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+var goLayoutTokens = map[string]string{
+	"YYYY": "2006",
+	"YY":   "06",
+	"MMMM": "January",
+	"MMM":  "Jan",
+	"MM":   "01",
+	"M":    "1",
+	"DDDD": "002",
+	"DD":   "02",
+	"D":    "2",
+	"dddd": "Monday",
+	"ddd":  "Mon",
+	"HH":   "15",
+	"hh":   "03",
+	"h":    "3",
+	"mm":   "04",
+	"m":    "4",
+	"ss":   "05",
+	"s":    "5",
+	"A":    "PM",
+	"a":    "pm",
+	"ZZ":   "-0700",
+	"Z":    "-07:00",
+}
+
+// MomentToGoLayout translates a moment.js format into an equivalent Go
+// reference layout. It reports false if the format uses tokens or literals
+// that cannot be expressed as a Go layout.
+func MomentToGoLayout(format string) (string, bool) {
+	var b strings.Builder
+	for _, tok := range tokenizeMoment(format) {
+		if tok.token == "" {
+			b.WriteString(tok.literal)
+			continue
+		}
+		layout, ok := goLayoutTokens[tok.token]
+		if !ok {
+			return "", false
+		}
+		b.WriteString(layout)
+	}
+
+	layout := b.String()
+	sample := time.Date(2025, time.November, 3, 21, 7, 9, 0, time.UTC)
+	if sample.Format(layout) != FormatMoment(sample, format) {
+		return "", false
+	}
+	return layout, true
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Defaults match the Periodic Notes plugin, so review notes land where it
+// looks for weekly and monthly notes.
+const (
+	DefaultWeeklyNoteFormat  = "gggg-[W]ww"
+	DefaultMonthlyNoteFormat = "YYYY-MM"
+	DefaultReviewHeading     = "Altum Review"
+)
+
+// PeriodicNotes locates weekly or monthly notes. Like daily notes, formats
+// may contain "/" to nest notes in folders.
+type PeriodicNotes struct {
+	FolderPath string
+	DateFormat DateFormat
+}
+
+func (p PeriodicNotes) NoteName(date time.Time) string {
+	return p.DateFormat.Format(date)
+}
+
+func (p PeriodicNotes) NotePath(date time.Time) string {
+	return filepath.Join(p.FolderPath, filepath.FromSlash(p.NoteName(date))+".md")
+}
+
+// SaveReview writes body under section in the note at path, creating the note
+// if needed. A section left by an earlier review is replaced; everything else
+// in the note is kept. The properties are set in the note's frontmatter, and
+// daily totals written by earlier versions are removed so that they aren't
+// counted along with the daily notes.
+func SaveReview(notePath string, section Section, body string, properties []Property) error {
+	return UpdateNote(notePath, func(content string, exists bool) (string, error) {
+		updated := RemoveProperties(section.Replace(content, body), dailyPropertyKeys)
+		return SetProperties(updated, properties), nil
+	})
+}
+
+// Replace sets the content of the section, creating it at the section's
+// position if the note doesn't have it yet.
+func (s Section) Replace(content, body string) string {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
+
+	bodyStart := 0
+	if properties, _, ok := SplitFrontmatter(content); ok {
+		bodyStart = len(properties) + 2
+	}
+
+	block := append([]string{s.Title(), ""}, strings.Split(strings.TrimRight(body, "\n"), "\n")...)
+
+	start, end := s.find(lines, bodyStart)
+	if start < 0 {
+		start = s.insertionPoint(lines, bodyStart)
+		end = start
+	}
+
+	var updated []string
+	updated = append(updated, lines[:start]...)
+	if start > bodyStart && strings.TrimSpace(lines[start-1]) != "" {
+		updated = append(updated, "")
+	}
+	updated = append(updated, block...)
+	if end < len(lines) {
+		updated = append(updated, "")
+		for end < len(lines) && strings.TrimSpace(lines[end]) == "" {
+			end++
+		}
+	}
+	updated = append(updated, lines[end:]...)
+
+	return strings.Join(updated, "\n") + "\n"
+}
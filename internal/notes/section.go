@@ -0,0 +1,197 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultSectionHeading = "Altum Work Sessions"
+	DefaultSectionLevel   = 2
+
+	PositionTop         = "top"
+	PositionBottom      = "bottom"
+	positionAfterPrefix = "after:"
+)
+
+// Section describes the heading Altum logs sessions under, and where that
+// heading is created when a note doesn't have it yet: "top", "bottom" or
+// "after:<heading>" to place it after another section of the note.
+type Section struct {
+	Heading  string
+	Level    int
+	Position string
+}
+
+func (s Section) heading() string {
+	if s.Heading == "" {
+		return DefaultSectionHeading
+	}
+	return s.Heading
+}
+
+func (s Section) level() int {
+	if s.Level < 1 || s.Level > 3 {
+		return DefaultSectionLevel
+	}
+	return s.Level
+}
+
+func (s Section) Title() string {
+	return strings.Repeat("#", s.level()) + " " + s.heading()
+}
+
+// InsertSession adds a session entry at the end of the Altum section,
+// creating the section if needed. The updated note and the new session's
+// number are returned.
+func (c Config) InsertSession(content string, date time.Time, entry Entry) (string, int, error) {
+	s := c.Section
+	format := c.format()
+
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
+
+	bodyStart := 0
+	if properties, _, ok := SplitFrontmatter(content); ok {
+		bodyStart = len(properties) + 2
+	}
+
+	start, end := s.find(lines, bodyStart)
+	number := 1
+	hasHeader := false
+	if start >= 0 {
+		for _, line := range lines[start+1 : end] {
+			line = strings.TrimSpace(line)
+			for _, f := range c.formats() {
+				if f.isStart(line) {
+					number++
+					break
+				}
+			}
+			if len(format.header) > 0 && line == format.header[0] {
+				hasHeader = true
+			}
+		}
+	}
+
+	entry.Start = entry.Start.In(c.location())
+	entry.End = entry.End.In(c.location())
+	rendered, err := format.render(number, date, entry)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to render session entry: %w", err)
+	}
+	block := strings.Split(strings.TrimRight(rendered, "\n"), "\n")
+	if format.compact() && !hasHeader {
+		block = append(append([]string{}, format.header...), block...)
+	}
+
+	if start < 0 {
+		block = append([]string{s.Title(), ""}, block...)
+		start = s.insertionPoint(lines, bodyStart)
+		end = start
+	} else {
+		start++
+	}
+
+	insertAt := end
+	for insertAt > start && strings.TrimSpace(lines[insertAt-1]) == "" {
+		insertAt--
+	}
+
+	var updated []string
+	updated = append(updated, lines[:insertAt]...)
+	if insertAt > bodyStart && strings.TrimSpace(lines[insertAt-1]) != "" && !(format.compact() && hasHeader) {
+		updated = append(updated, "")
+	}
+	updated = append(updated, block...)
+	if end < len(lines) {
+		updated = append(updated, "")
+	}
+	updated = append(updated, lines[end:]...)
+
+	return strings.Join(updated, "\n") + "\n", number, nil
+}
+
+// find returns the line index of the section heading and the index of the
+// first line after the section, or -1 if the note has no such section.
+func (s Section) find(lines []string, from int) (int, int) {
+	return findSection(lines, from, s.Title())
+}
+
+func (s Section) insertionPoint(lines []string, bodyStart int) int {
+	position := strings.TrimSpace(s.Position)
+
+	switch {
+	case position == PositionTop:
+		return bodyStart
+	case strings.HasPrefix(position, positionAfterPrefix):
+		heading := strings.TrimSpace(strings.TrimPrefix(position, positionAfterPrefix))
+		if !strings.HasPrefix(heading, "#") {
+			for i := bodyStart; i < len(lines); i++ {
+				if level, text := headingLevel(lines[i]); level > 0 && text == heading {
+					heading = strings.TrimSpace(lines[i])
+					break
+				}
+			}
+		}
+		if start, end := findSection(lines, bodyStart, heading); start >= 0 {
+			return end
+		}
+	}
+
+	return len(lines)
+}
+
+func findSection(lines []string, from int, title string) (int, int) {
+	level, _ := headingLevel(title)
+	if level == 0 {
+		return -1, -1
+	}
+
+	start := -1
+	inFence := false
+	for i := from; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		lineLevel, _ := headingLevel(line)
+		if start < 0 {
+			if lineLevel > 0 && line == title {
+				start = i
+			}
+			continue
+		}
+		if lineLevel > 0 && lineLevel <= level {
+			return start, i
+		}
+	}
+
+	if start < 0 {
+		return -1, -1
+	}
+	return start, len(lines)
+}
+
+func headingLevel(line string) (int, string) {
+	line = strings.TrimSpace(line)
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level >= len(line) || line[level] != ' ' {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(line[level:])
+}
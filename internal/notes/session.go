@@ -0,0 +1,201 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Session struct {
+	Date          time.Time
+	Start         time.Time
+	End           time.Time
+	Duration      time.Duration
+	FocusQuality  int
+	Milestone     string
+	Interruptions string
+	Reflection    string
+	Continued     bool
+
+	clockOnly bool
+}
+
+var (
+	durationRe  = regexp.MustCompile(`^(\d+) minutes (\d+) seconds$`)
+	timestampRe = regexp.MustCompile(`^(?:(\d{4}-\d{2}-\d{2}) )?(\d{1,2}:\d{2}(?::\d{2})?)$`)
+)
+
+func (c Config) ParseFile(filePath string, date time.Time) ([]Session, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return c.ParseSessions(file, date)
+}
+
+// ParseSessions reads the session entries logged under the Altum section of
+// a note. Entries are recognised in the configured format and, so that older
+// notes stay readable after switching formats, in the default bullet format.
+func (c Config) ParseSessions(r io.Reader, date time.Time) ([]Session, error) {
+	var sessions []Session
+	scanner := bufio.NewScanner(r)
+
+	var currentSession *Session
+	inSessionsSection := false
+	title := c.Section.Title()
+	sectionLevel := c.Section.level()
+	formats := c.formats()
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if level, _ := headingLevel(line); level > 0 && level <= sectionLevel {
+			inSessionsSection = line == title
+			if !inSessionsSection && currentSession != nil {
+				sessions = append(sessions, currentSession.finish())
+				currentSession = nil
+			}
+			continue
+		}
+
+		if !inSessionsSection {
+			continue
+		}
+
+		for _, format := range formats {
+			ok, start, values := format.match(line)
+			if !ok {
+				continue
+			}
+			if start {
+				if currentSession != nil {
+					sessions = append(sessions, currentSession.finish())
+				}
+				currentSession = &Session{
+					Date: date,
+				}
+			}
+			if currentSession != nil {
+				currentSession.apply(values, c)
+			}
+			break
+		}
+	}
+
+	if currentSession != nil {
+		sessions = append(sessions, currentSession.finish())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (s *Session) apply(values map[string]string, c Config) {
+	if value, ok := values["Number"]; ok {
+		s.Continued = strings.HasSuffix(value, continuedSuffix)
+	}
+
+	if value, ok := values["Duration"]; ok {
+		if matches := durationRe.FindStringSubmatch(value); matches != nil {
+			minutes, _ := strconv.Atoi(matches[1])
+			seconds, _ := strconv.Atoi(matches[2])
+			s.Duration = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+		}
+	}
+
+	if value, ok := values["Minutes"]; ok {
+		minutes, _ := strconv.Atoi(value)
+		s.Duration = s.Duration%time.Minute + time.Duration(minutes)*time.Minute
+	}
+
+	if value, ok := values["Seconds"]; ok {
+		seconds, _ := strconv.Atoi(value)
+		s.Duration = s.Duration.Truncate(time.Minute) + time.Duration(seconds)*time.Second
+	}
+
+	if value, ok := values["FocusQuality"]; ok {
+		s.FocusQuality, _ = strconv.Atoi(value)
+	}
+
+	if value, ok := values["Milestone"]; ok {
+		s.Milestone = value
+	}
+
+	if value, ok := values["Interruptions"]; ok {
+		s.Interruptions = value
+	}
+
+	if value, ok := values["Reflection"]; ok {
+		s.Reflection = value
+	}
+
+	if value, ok := values["Start"]; ok {
+		s.Start, s.clockOnly = c.parseTimestamp(s.Date, value)
+	}
+
+	if value, ok := values["End"]; ok {
+		var clockOnly bool
+		s.End, clockOnly = c.parseTimestamp(s.Date, value)
+		s.clockOnly = s.clockOnly && clockOnly
+	}
+}
+
+// finish resolves times logged without dates. Before dates were written for
+// sessions crossing midnight, they were logged in the note of the day they
+// ended, so an end time before the start time means the session started the
+// previous day.
+func (s *Session) finish() Session {
+	if s.clockOnly && !s.Start.IsZero() && s.End.Before(s.Start) {
+		s.Start = s.Start.AddDate(0, 0, -1)
+	}
+	return *s
+}
+
+// parseTimestamp reads a logged start or end time. Times without a date are
+// on the note's logical day, so with a day_starts_at of 04:00 a time of 01:30
+// falls on the following calendar date.
+func (c Config) parseTimestamp(noteDate time.Time, value string) (time.Time, bool) {
+	matches := timestampRe.FindStringSubmatch(value)
+	if matches == nil {
+		return time.Time{}, false
+	}
+
+	date := noteDate
+	if matches[1] != "" {
+		parsed, err := time.Parse("2006-01-02", matches[1])
+		if err != nil {
+			return time.Time{}, false
+		}
+		date = parsed
+	}
+
+	layout := "15:04:05"
+	if strings.Count(matches[2], ":") == 1 {
+		layout = "15:04"
+	}
+	clock, err := time.Parse(layout, matches[2])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	clockOnly := matches[1] == ""
+	sinceMidnight := time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute + time.Duration(clock.Second())*time.Second
+	day := date.Day()
+	if clockOnly && sinceMidnight < c.DayStartsAt {
+		day++
+	}
+
+	return time.Date(date.Year(), date.Month(), day, clock.Hour(), clock.Minute(), clock.Second(), 0, c.location()), clockOnly
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	PropertyMinutes         = "deep_work_minutes"
+	PropertySessions        = "deep_work_sessions"
+	PropertyAvgFocusQuality = "avg_focus_quality"
+	PropertyLongestSession  = "longest_session_minutes"
+	PropertyRatedSessions   = "rated_sessions"
+)
+
+type Summary struct {
+	Sessions          int
+	Duration          time.Duration
+	FocusQualityTotal int
+	FocusQualityCount int
+	LongestSession    time.Duration
+}
+
+func Summarize(sessions []Session) Summary {
+	var summary Summary
+	for _, session := range sessions {
+		summary.Duration += session.Duration
+		if session.Continued {
+			continue
+		}
+		summary.Sessions++
+		if session.FocusQuality > 0 {
+			summary.FocusQualityTotal += session.FocusQuality
+			summary.FocusQualityCount++
+		}
+		if session.Duration > summary.LongestSession {
+			summary.LongestSession = session.Duration
+		}
+	}
+	return summary
+}
+
+func (s *Summary) Add(other Summary) {
+	s.Sessions += other.Sessions
+	s.Duration += other.Duration
+	s.FocusQualityTotal += other.FocusQualityTotal
+	s.FocusQualityCount += other.FocusQualityCount
+	if other.LongestSession > s.LongestSession {
+		s.LongestSession = other.LongestSession
+	}
+}
+
+func (s Summary) AvgFocusQuality() float64 {
+	if s.FocusQualityCount == 0 {
+		return 0
+	}
+	return float64(s.FocusQualityTotal) / float64(s.FocusQualityCount)
+}
+
+func (s Summary) Properties() []Property {
+	return []Property{
+		{Key: PropertyMinutes, Value: strconv.Itoa(int(math.Round(s.Duration.Minutes())))},
+		{Key: PropertySessions, Value: strconv.Itoa(s.Sessions)},
+		{Key: PropertyAvgFocusQuality, Value: strconv.FormatFloat(s.AvgFocusQuality(), 'f', 1, 64)},
+		{Key: PropertyLongestSession, Value: strconv.Itoa(int(math.Round(s.LongestSession.Minutes())))},
+		{Key: PropertyRatedSessions, Value: strconv.Itoa(s.FocusQualityCount)},
+	}
+}
+
+// reviewPropertyPrefix sets the totals of a review apart from those of daily
+// notes, which may share a folder and be summed by the same query.
+const reviewPropertyPrefix = "review_"
+
+// ReviewProperties returns the properties for a period's review note: the
+// same totals as Properties, under review_ keys.
+func (s Summary) ReviewProperties() []Property {
+	properties := s.Properties()
+	for i := range properties {
+		properties[i].Key = reviewPropertyPrefix + properties[i].Key
+	}
+	return properties
+}
+
+// dailyPropertyKeys are the keys of Properties.
+var dailyPropertyKeys = []string{
+	PropertyMinutes, PropertySessions, PropertyAvgFocusQuality, PropertyLongestSession, PropertyRatedSessions,
+}
+
+// SummaryFromProperties rebuilds a day's aggregates from the frontmatter
+// written by Altum. It reports false if the note has no Altum properties.
+func SummaryFromProperties(properties map[string]string) (Summary, bool) {
+	sessions, err := strconv.Atoi(properties[PropertySessions])
+	if err != nil {
+		return Summary{}, false
+	}
+	minutes, err := strconv.ParseFloat(properties[PropertyMinutes], 64)
+	if err != nil {
+		return Summary{}, false
+	}
+
+	summary := Summary{
+		Sessions: sessions,
+		Duration: time.Duration(minutes * float64(time.Minute)),
+	}
+
+	// Notes written before rated sessions were counted are taken to have
+	// every session rated.
+	rated, err := strconv.Atoi(properties[PropertyRatedSessions])
+	if err != nil {
+		rated = sessions
+	}
+	if avg, err := strconv.ParseFloat(properties[PropertyAvgFocusQuality], 64); err == nil && avg > 0 {
+		summary.FocusQualityTotal = int(math.Round(avg * float64(rated)))
+		summary.FocusQualityCount = rated
+	}
+	if longest, err := strconv.ParseFloat(properties[PropertyLongestSession], 64); err == nil {
+		summary.LongestSession = time.Duration(longest * float64(time.Minute))
+	}
+
+	return summary, true
+}
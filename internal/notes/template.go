@@ -0,0 +1,81 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTemplateDateFormat = "YYYY-MM-DD"
+	defaultTemplateTimeFormat = "HH:mm"
+)
+
+var templateVariableRe = regexp.MustCompile(`\{\{\s*(\w+)\s*(?::([^}]*))?\}\}`)
+
+// NewNoteContent returns the initial content for a daily note that does not
+// exist yet, rendered from the configured template.
+func (c Config) NewNoteContent(date time.Time) (string, error) {
+	templatePath := c.ResolveTemplatePath()
+	if templatePath == "" {
+		return "", nil
+	}
+
+	template, err := os.ReadFile(templatePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read daily note template: %w", err)
+	}
+
+	return c.RenderTemplate(string(template), date), nil
+}
+
+// RenderTemplate substitutes the variables supported by Obsidian's core
+// Templates and Periodic Notes plugins: {{title}}, {{date}}, {{time}},
+// {{weekday}}, {{yesterday}} and {{tomorrow}}, each optionally followed by a
+// moment.js format such as {{date:dddd, MMMM Do}}. Unknown variables are left
+// untouched so other template plugins can still process them.
+func (c Config) RenderTemplate(template string, date time.Time) string {
+	now := time.Now().In(c.location())
+	noteTime := time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), now.Second(), 0, date.Location())
+
+	return templateVariableRe.ReplaceAllStringFunc(template, func(match string) string {
+		parts := templateVariableRe.FindStringSubmatch(match)
+		name := strings.ToLower(parts[1])
+		format := strings.TrimSpace(parts[2])
+
+		switch name {
+		case "title":
+			return c.NoteTitle(date)
+		case "date":
+			return FormatMoment(noteTime, withDefault(format, defaultTemplateDateFormat))
+		case "time":
+			return FormatMoment(noteTime, withDefault(format, defaultTemplateTimeFormat))
+		case "weekday":
+			return FormatMoment(noteTime, withDefault(format, "dddd"))
+		case "yesterday":
+			return c.relativeName(date.AddDate(0, 0, -1), format)
+		case "tomorrow":
+			return c.relativeName(date.AddDate(0, 0, 1), format)
+		}
+		return match
+	})
+}
+
+func (c Config) relativeName(date time.Time, format string) string {
+	if format == "" {
+		return c.NoteTitle(date)
+	}
+	return FormatMoment(date, format)
+}
+
+func withDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
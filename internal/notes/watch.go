@@ -0,0 +1,121 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long the folder must be quiet before changes are
+// reported, so that a save touching a note several times, or a sync bringing
+// in many notes, is reported once.
+const watchDebounce = 300 * time.Millisecond
+
+// Watch watches the daily notes folder, including nested folders, and calls
+// onChange with the notes created, changed or removed once changes settle.
+// A nil list means changes were missed and every note may have changed.
+// Watch only returns if watching fails.
+func (c Config) Watch(onChange func([]NoteFile)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if _, err := c.watchFolder(watcher, c.FolderPath); err != nil {
+		return err
+	}
+
+	changed := make(map[string]NoteFile)
+	missed := false
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			// Folders created or moved in are watched too, along with any
+			// notes already in them.
+			if event.Has(fsnotify.Create) {
+				if files, err := c.watchFolder(watcher, event.Name); err == nil {
+					for _, file := range files {
+						changed[file.Path] = file
+					}
+				}
+			}
+
+			if strings.HasSuffix(event.Name, ".md") {
+				if file, ok := c.noteFile(event.Name); ok {
+					changed[file.Path] = file
+				}
+			}
+			if len(changed) > 0 || missed {
+				timer.Reset(watchDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			if !errors.Is(err, fsnotify.ErrEventOverflow) {
+				return err
+			}
+			missed = true
+			timer.Reset(watchDebounce)
+
+		case <-timer.C:
+			var files []NoteFile
+			if !missed {
+				for _, file := range changed {
+					files = append(files, file)
+				}
+				sort.Slice(files, func(i, j int) bool {
+					return files[i].Date.Before(files[j].Date)
+				})
+			}
+			changed = make(map[string]NoteFile)
+			missed = false
+			onChange(files)
+		}
+	}
+}
+
+// watchFolder adds root and the folders below it to the watcher, skipping
+// hidden ones as DailyNoteFiles does, and returns the notes found in them.
+func (c Config) watchFolder(watcher *fsnotify.Watcher, root string) ([]NoteFile, error) {
+	var files []NoteFile
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			if strings.HasSuffix(path, ".md") {
+				if file, ok := c.noteFile(path); ok {
+					files = append(files, file)
+				}
+			}
+			return nil
+		}
+		if path != c.FolderPath && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+	return files, err
+}
@@ -0,0 +1,209 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	lockTimeout   = 5 * time.Second
+	updateRetries = 3
+)
+
+var (
+	ErrLocked   = errors.New("note is locked by another altum process")
+	ErrConflict = errors.New("note was modified by another program while saving")
+
+	// errChanged is returned by writeAtomicIf when the file changed after
+	// it was read.
+	errChanged = errors.New("file changed while writing")
+)
+
+type noteSnapshot struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+	hash    [sha256.Size]byte
+	mode    fs.FileMode
+	content []byte
+}
+
+// UpdateNote rewrites the note at path with the result of update while
+// holding an advisory lock, so concurrent altum processes can't interleave.
+// The new content is written to a temporary file and renamed into place. If
+// another program (an editor or sync client) changes the note between reading
+// it and the rename, the update is retried and ErrConflict returned if it
+// keeps happening.
+func UpdateNote(path string, update func(content string, exists bool) (string, error)) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	unlock, err := lockNote(path, lockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for attempt := 0; attempt < updateRetries; attempt++ {
+		before, err := snapshotNote(path)
+		if err != nil {
+			return err
+		}
+
+		updated, err := update(string(before.content), before.exists)
+		if err != nil {
+			return err
+		}
+
+		err = writeAtomicIf(path, []byte(updated), before.mode, func() (bool, error) {
+			after, err := snapshotNote(path)
+			return before.same(after), err
+		})
+		if errors.Is(err, errChanged) {
+			continue
+		}
+		return err
+	}
+
+	return fmt.Errorf("%w: %s", ErrConflict, path)
+}
+
+// SaveSession logs entry in the daily note for date, creating the note from
+// the template if needed and refreshing its frontmatter totals. It returns
+// the note's path and the new session's number.
+func (c Config) SaveSession(date time.Time, entry Entry) (string, int, error) {
+	notePath := c.NotePath(date)
+	number := 0
+
+	err := UpdateNote(notePath, func(content string, exists bool) (string, error) {
+		if !exists {
+			newNote, err := c.NewNoteContent(date)
+			if err != nil {
+				return "", err
+			}
+			content = newNote
+		}
+
+		updated, n, err := c.InsertSession(content, date, entry)
+		if err != nil {
+			return "", err
+		}
+		number = n
+
+		sessions, err := c.ParseSessions(strings.NewReader(updated), date)
+		if err != nil {
+			return "", err
+		}
+		return SetProperties(updated, Summarize(sessions).Properties()), nil
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	return notePath, number, nil
+}
+
+func snapshotNote(path string) (noteSnapshot, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return noteSnapshot{mode: 0644}, nil
+	}
+	if err != nil {
+		return noteSnapshot{}, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return noteSnapshot{}, err
+	}
+
+	return noteSnapshot{
+		exists:  true,
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		hash:    sha256.Sum256(content),
+		mode:    info.Mode().Perm(),
+		content: content,
+	}, nil
+}
+
+func (s noteSnapshot) same(other noteSnapshot) bool {
+	return s.exists == other.exists &&
+		s.modTime.Equal(other.modTime) &&
+		s.size == other.size &&
+		bytes.Equal(s.hash[:], other.hash[:])
+}
+
+func writeAtomic(path string, content []byte, mode fs.FileMode) error {
+	return writeAtomicIf(path, content, mode, nil)
+}
+
+// writeAtomicIf writes content to a temporary file and renames it over path.
+// If unchanged is given, it is checked just before the rename, and
+// errChanged returned instead of replacing a file that changed meanwhile.
+func writeAtomicIf(path string, content []byte, mode fs.FileMode, unchanged func() (bool, error)) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return err
+	}
+
+	if unchanged != nil {
+		ok, err := unchanged()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errChanged
+		}
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// lockPath keeps lock files out of the vault so they are never synced or
+// shown by Obsidian.
+func lockPath(notePath string) (string, error) {
+	abs, err := filepath.Abs(notePath)
+	if err != nil {
+		return "", err
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	lockDir := filepath.Join(cacheDir, "altum", "locks")
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(lockDir, fmt.Sprintf("%x.lock", sha256.Sum256([]byte(abs)))), nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package notes
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestUpdateNoteConflict(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "2025-11-15.md")
+	if err := os.WriteFile(path, []byte("original\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// An editor saving the note while every update is being prepared.
+	edits := 0
+	err := UpdateNote(path, func(content string, exists bool) (string, error) {
+		edits++
+		if err := os.WriteFile(path, []byte("edited elsewhere "+strconv.Itoa(edits)+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return content + "session\n", nil
+	})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("UpdateNote() error = %v, want %v", err, ErrConflict)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "edited elsewhere 3\n"; string(content) != want {
+		t.Errorf("note = %q, want the last outside edit %q", content, want)
+	}
+}
+
+func TestUpdateNote(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "2025-11-15.md")
+
+	err := UpdateNote(path, func(content string, exists bool) (string, error) {
+		if exists {
+			t.Errorf("UpdateNote() exists = true for a new note")
+		}
+		return "session\n", nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateNote() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "session\n" {
+		t.Errorf("note = %q, want %q", content, "session\n")
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package obsidian
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	configDirName = ".obsidian"
+
+	defaultDailyNoteFormat   = "YYYY-MM-DD"
+	defaultWeeklyNoteFormat  = "gggg-[W]ww"
+	defaultMonthlyNoteFormat = "YYYY-MM"
+)
+
+type DailyNotesSettings struct {
+	Folder   string
+	Format   string
+	Template string
+	Source   string
+}
+
+// PeriodicNotesSettings locate the weekly or monthly notes of the Periodic
+// Notes plugin.
+type PeriodicNotesSettings struct {
+	Folder string
+	Format string
+}
+
+type Vault struct {
+	Path       string
+	DailyNotes DailyNotesSettings
+
+	// WeeklyNotes and MonthlyNotes are nil unless enabled in Periodic Notes.
+	WeeklyNotes  *PeriodicNotesSettings
+	MonthlyNotes *PeriodicNotesSettings
+}
+
+type dailyNotesConfig struct {
+	Folder   string `json:"folder"`
+	Format   string `json:"format"`
+	Template string `json:"template"`
+}
+
+type periodicNoteConfig struct {
+	Enabled      bool   `json:"enabled"`
+	Folder       string `json:"folder"`
+	Format       string `json:"format"`
+	Template     string `json:"template"`
+	TemplatePath string `json:"templatePath"`
+}
+
+type periodicNotesConfig struct {
+	Daily        *periodicNoteConfig `json:"daily"`
+	Weekly       *periodicNoteConfig `json:"weekly"`
+	Monthly      *periodicNoteConfig `json:"monthly"`
+	CalendarSets []struct {
+		Day   *periodicNoteConfig `json:"day"`
+		Week  *periodicNoteConfig `json:"week"`
+		Month *periodicNoteConfig `json:"month"`
+	} `json:"calendarSets"`
+}
+
+// FindVaultUp walks up from start until it finds a directory containing a
+// .obsidian folder.
+func FindVaultUp(start string) (string, bool) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		if isVault(dir) {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// SearchVaults looks for vaults below each root, descending at most maxDepth
+// directories and skipping hidden folders.
+func SearchVaults(roots []string, maxDepth int) []string {
+	var vaults []string
+	seen := make(map[string]bool)
+
+	for _, root := range roots {
+		root = filepath.Clean(root)
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return nil
+			}
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			if isVault(path) {
+				if !seen[path] {
+					seen[path] = true
+					vaults = append(vaults, path)
+				}
+				return filepath.SkipDir
+			}
+			rel, _ := filepath.Rel(root, path)
+			if rel != "." && strings.Count(rel, string(filepath.Separator))+1 >= maxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+	}
+
+	return vaults
+}
+
+// LoadVault reads the daily notes settings of the vault at path. The Periodic
+// Notes plugin takes precedence over the core Daily Notes plugin when its
+// daily notes are enabled, matching Obsidian's own behaviour.
+func LoadVault(path string) (Vault, error) {
+	if !isVault(path) {
+		return Vault{}, fmt.Errorf("%s is not an Obsidian vault", path)
+	}
+
+	vault := Vault{
+		Path: path,
+		DailyNotes: DailyNotesSettings{
+			Format: defaultDailyNoteFormat,
+			Source: "defaults",
+		},
+	}
+
+	var daily dailyNotesConfig
+	found, err := readJSON(filepath.Join(path, configDirName, "daily-notes.json"), &daily)
+	if err != nil {
+		return Vault{}, err
+	}
+	if found {
+		vault.DailyNotes = DailyNotesSettings{
+			Folder:   daily.Folder,
+			Format:   withDefault(daily.Format, defaultDailyNoteFormat),
+			Template: daily.Template,
+			Source:   "Daily notes",
+		}
+	}
+
+	var periodic periodicNotesConfig
+	found, err = readJSON(filepath.Join(path, configDirName, "plugins", "periodic-notes", "data.json"), &periodic)
+	if err != nil {
+		return Vault{}, err
+	}
+	if found {
+		if day := periodic.dailyConfig(); day != nil && day.Enabled {
+			vault.DailyNotes = DailyNotesSettings{
+				Folder:   day.Folder,
+				Format:   withDefault(day.Format, defaultDailyNoteFormat),
+				Template: withDefault(day.TemplatePath, day.Template),
+				Source:   "Periodic Notes",
+			}
+		}
+		if week := periodic.weeklyConfig(); week != nil && week.Enabled {
+			vault.WeeklyNotes = &PeriodicNotesSettings{
+				Folder: week.Folder,
+				Format: withDefault(week.Format, defaultWeeklyNoteFormat),
+			}
+		}
+		if month := periodic.monthlyConfig(); month != nil && month.Enabled {
+			vault.MonthlyNotes = &PeriodicNotesSettings{
+				Folder: month.Folder,
+				Format: withDefault(month.Format, defaultMonthlyNoteFormat),
+			}
+		}
+	}
+
+	return vault, nil
+}
+
+// FolderPath returns the absolute path of the vault's daily notes folder.
+func (v Vault) FolderPath() string {
+	return v.NotesFolderPath(v.DailyNotes.Folder)
+}
+
+// NotesFolderPath returns the absolute path of a folder given relative to the
+// vault, as plugins store them.
+func (v Vault) NotesFolderPath(folder string) string {
+	return filepath.Join(v.Path, filepath.FromSlash(folder))
+}
+
+// TemplatePath returns the absolute path of the daily note template, or an
+// empty string if none is configured.
+func (v Vault) TemplatePath() string {
+	if v.DailyNotes.Template == "" {
+		return ""
+	}
+	path := filepath.Join(v.Path, filepath.FromSlash(v.DailyNotes.Template))
+	if filepath.Ext(path) == "" {
+		path += ".md"
+	}
+	return path
+}
+
+func (c periodicNotesConfig) dailyConfig() *periodicNoteConfig {
+	if c.Daily != nil {
+		return c.Daily
+	}
+	for _, set := range c.CalendarSets {
+		if set.Day != nil {
+			return set.Day
+		}
+	}
+	return nil
+}
+
+func (c periodicNotesConfig) weeklyConfig() *periodicNoteConfig {
+	if c.Weekly != nil {
+		return c.Weekly
+	}
+	for _, set := range c.CalendarSets {
+		if set.Week != nil {
+			return set.Week
+		}
+	}
+	return nil
+}
+
+func (c periodicNotesConfig) monthlyConfig() *periodicNoteConfig {
+	if c.Monthly != nil {
+		return c.Monthly
+	}
+	for _, set := range c.CalendarSets {
+		if set.Month != nil {
+			return set.Month
+		}
+	}
+	return nil
+}
+
+func isVault(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, configDirName))
+	return err == nil && info.IsDir()
+}
+
+func readJSON(path string, v any) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return true, nil
+}
+
+func withDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,330 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"altum/internal/notes"
+)
+
+const (
+	DefaultWidth = 80
+	minChartBar  = 10
+	maxChartBar  = 60
+)
+
+// Charset holds the characters charts and text reports are drawn with.
+type Charset struct {
+	Bar rune
+	// Partials are the fractional bar ends, from an eighth up to seven
+	// eighths. Bars are rounded to whole characters without them.
+	Partials []rune
+	// Spark are the sparkline levels, lowest first.
+	Spark []rune
+	Axis  string
+	// Up, Down and Same mark changes from the previous period.
+	Up, Down, Same string
+	// Star and NoStar draw focus ratings out of five.
+	Star, NoStar string
+	// Rule is repeated to frame headings.
+	Rule string
+	// Dash, Ellipsis and PlusMinus punctuate report lines.
+	Dash, Ellipsis, PlusMinus string
+}
+
+var (
+	UnicodeCharset = Charset{
+		Bar:       '█',
+		Partials:  []rune("▏▎▍▌▋▊▉"),
+		Spark:     []rune("▁▂▃▄▅▆▇█"),
+		Axis:      "│",
+		Up:        "▲",
+		Down:      "▼",
+		Same:      "=",
+		Star:      "★",
+		NoStar:    "☆",
+		Rule:      "═",
+		Dash:      "–",
+		Ellipsis:  "…",
+		PlusMinus: "±",
+	}
+	ASCIICharset = Charset{
+		Bar:       '#',
+		Spark:     []rune("_.-~=*#"),
+		Axis:      "|",
+		Up:        "^",
+		Down:      "v",
+		Same:      "=",
+		Star:      "*",
+		NoStar:    "-",
+		Rule:      "=",
+		Dash:      "-",
+		Ellipsis:  "...",
+		PlusMinus: "+/-",
+	}
+)
+
+// BarRow is one bar of a chart, with the text shown after it.
+type BarRow struct {
+	Label string
+	Value float64
+	Text  string
+}
+
+// writeBarChart draws one horizontal bar per row, scaled to the largest
+// value and fitted to width.
+func writeBarChart(b *strings.Builder, rows []BarRow, width int, cs Charset) {
+	largest := 0.0
+	for _, row := range rows {
+		largest = math.Max(largest, row.Value)
+	}
+	writeScaledBarChart(b, rows, largest, width, cs)
+}
+
+// writeScaledBarChart draws bars with full bars at largest.
+func writeScaledBarChart(b *strings.Builder, rows []BarRow, largest float64, width int, cs Charset) {
+	labelWidth, textWidth := 0, 0
+	for _, row := range rows {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(row.Label))
+		textWidth = max(textWidth, utf8.RuneCountInString(row.Text))
+	}
+
+	barWidth := min(max(width-labelWidth-textWidth-4, minChartBar), maxChartBar)
+	for _, row := range rows {
+		bar := Bar(row.Value, largest, barWidth, cs)
+		fmt.Fprintf(b, "%s %s%s%s %s\n",
+			padRight(row.Label, labelWidth),
+			cs.Axis,
+			bar,
+			strings.Repeat(" ", barWidth-utf8.RuneCountInString(bar)),
+			row.Text)
+	}
+}
+
+func Bar(value, largest float64, width int, cs Charset) string {
+	if largest <= 0 || value <= 0 {
+		return ""
+	}
+
+	length := value / largest * float64(width)
+	if len(cs.Partials) == 0 {
+		return strings.Repeat(string(cs.Bar), int(math.Round(length)))
+	}
+
+	full := int(length)
+	bar := strings.Repeat(string(cs.Bar), full)
+	steps := len(cs.Partials) + 1
+	if eighths := int(math.Round((length - float64(full)) * float64(steps))); eighths == steps {
+		bar += string(cs.Bar)
+	} else if eighths > 0 {
+		bar += string(cs.Partials[eighths-1])
+	}
+	return bar
+}
+
+// Sparkline draws values between low and high as one character each,
+// leaving a gap for NaN. Values are averaged in groups to fit width.
+func Sparkline(values []float64, low, high float64, width int, cs Charset) string {
+	values = fitValues(values, width)
+
+	var b strings.Builder
+	for _, value := range values {
+		if math.IsNaN(value) {
+			b.WriteRune(' ')
+			continue
+		}
+		level := 0
+		if high > low {
+			level = int(math.Round((value - low) / (high - low) * float64(len(cs.Spark)-1)))
+		}
+		level = min(max(level, 0), len(cs.Spark)-1)
+		b.WriteRune(cs.Spark[level])
+	}
+	return b.String()
+}
+
+func fitValues(values []float64, width int) []float64 {
+	if width <= 0 || len(values) <= width {
+		return values
+	}
+
+	fitted := make([]float64, width)
+	for i := range fitted {
+		from := i * len(values) / width
+		to := (i + 1) * len(values) / width
+		total, count := 0.0, 0
+		for _, value := range values[from:to] {
+			if !math.IsNaN(value) {
+				total += value
+				count++
+			}
+		}
+		fitted[i] = math.NaN()
+		if count > 0 {
+			fitted[i] = total / float64(count)
+		}
+	}
+	return fitted
+}
+
+// DeepWorkRows totals deep work per day of the range up to today, or per
+// week or month when there are too many days for one row each.
+func (s *Stats) DeepWorkRows() []BarRow {
+	byDay := make(map[string]DayStats)
+	for _, day := range s.Days {
+		byDay[dayKey(day.Date)] = day
+	}
+
+	dates := s.ElapsedDates()
+	period := func(date time.Time) (string, string) {
+		return dayKey(date), date.Format("Mon Jan 2")
+	}
+	switch {
+	case len(dates) > 53*7:
+		period = func(date time.Time) (string, string) {
+			return date.Format("2006-01"), date.Format("Jan 2006")
+		}
+	case len(dates) > 31:
+		period = func(date time.Time) (string, string) {
+			monday := Week(date).Start
+			return dayKey(monday), "Week of " + monday.Format("Jan 2")
+		}
+	}
+
+	var rows []BarRow
+	var totals []time.Duration
+	last := ""
+	for _, date := range dates {
+		key, label := period(date)
+		if key != last {
+			rows = append(rows, BarRow{Label: label})
+			totals = append(totals, 0)
+			last = key
+		}
+		totals[len(totals)-1] += byDay[dayKey(date)].Duration
+	}
+
+	for i := range rows {
+		rows[i].Value = totals[i].Hours()
+		rows[i].Text = fmt.Sprintf("%.1fh", totals[i].Hours())
+	}
+	return rows
+}
+
+// FocusSeries returns the average focus quality of each day up to today,
+// NaN for days without rated sessions.
+func (s *Stats) FocusSeries() []float64 {
+	byDay := make(map[string]notes.Summary)
+	for _, day := range s.Days {
+		byDay[dayKey(day.Date)] = day.Summary
+	}
+
+	var values []float64
+	for _, date := range s.ElapsedDates() {
+		summary := byDay[dayKey(date)]
+		if summary.FocusQualityCount == 0 {
+			values = append(values, math.NaN())
+			continue
+		}
+		values = append(values, summary.AvgFocusQuality())
+	}
+	return values
+}
+
+var lengthBuckets = []struct {
+	label string
+	upTo  time.Duration
+}{
+	{"< 15m", 15 * time.Minute},
+	{"15-30m", 30 * time.Minute},
+	{"30-45m", 45 * time.Minute},
+	{"45-60m", 60 * time.Minute},
+	{"60-90m", 90 * time.Minute},
+	{"90-120m", 120 * time.Minute},
+	{"120m+", math.MaxInt64},
+}
+
+func lengthBucket(duration time.Duration) int {
+	for i, bucket := range lengthBuckets {
+		if duration < bucket.upTo {
+			return i
+		}
+	}
+	return len(lengthBuckets) - 1
+}
+
+// lengthRows counts sessions per length bucket, joining those split at
+// midnight so each is counted once at its whole length.
+func lengthRows(s *Stats) []BarRow {
+	counts := make([]int, len(lengthBuckets))
+	for _, session := range s.LoggedSessions() {
+		if session.Continued {
+			continue
+		}
+		counts[lengthBucket(session.Duration)]++
+	}
+
+	rows := make([]BarRow, len(lengthBuckets))
+	for i, bucket := range lengthBuckets {
+		rows[i] = BarRow{Label: bucket.label, Value: float64(counts[i]), Text: fmt.Sprint(counts[i])}
+	}
+	return rows
+}
+
+// ElapsedDates returns the days of the range up to today.
+func (s *Stats) ElapsedDates() []time.Time {
+	dates := s.Range.Dates()
+	if n := s.ElapsedDays(); n < len(dates) {
+		dates = dates[:n]
+	}
+	return dates
+}
+
+func (opts RenderOptions) width() int {
+	if opts.Width <= 0 {
+		return DefaultWidth
+	}
+	return opts.Width
+}
+
+func (opts RenderOptions) charset() Charset {
+	if opts.ASCII {
+		return ASCIICharset
+	}
+	return UnicodeCharset
+}
+
+func writeChartsText(b *strings.Builder, s *Stats, opts RenderOptions) {
+	width, cs := opts.width(), opts.charset()
+
+	if rows := s.DeepWorkRows(); len(rows) > 1 {
+		fmt.Fprintln(b, "Deep work:")
+		writeBarChart(b, rows, width, cs)
+		fmt.Fprintln(b)
+	}
+
+	if s.Total.FocusQualityCount > 0 && s.ElapsedDays() > 1 {
+		series := s.FocusSeries()
+		fmt.Fprintf(b, "Focus quality (%s - %s, 1-5):\n",
+			s.Range.Start.Format("Jan 2"),
+			s.ElapsedDates()[len(series)-1].Format("Jan 2"))
+		fmt.Fprintf(b, "%s %s\n", cs.Axis, Sparkline(series, 1, 5, width-2, cs))
+		fmt.Fprintln(b)
+	}
+
+	if len(s.Sessions) > 0 {
+		fmt.Fprintln(b, "Session lengths:")
+		writeBarChart(b, lengthRows(s), width, cs)
+		fmt.Fprintln(b)
+	}
+}
+
+func padRight(value string, width int) string {
+	return value + strings.Repeat(" ", max(width-utf8.RuneCountInString(value), 0))
+}
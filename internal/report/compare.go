@@ -0,0 +1,180 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Previous returns the period before the range: the previous week, month,
+// quarter or year for calendar ranges, or the same number of days before.
+func (r Range) Previous() Range {
+	switch r.Kind {
+	case RangeDay:
+		return Day(r.Start.AddDate(0, 0, -1))
+	case RangeWeek:
+		return Week(r.Start.AddDate(0, 0, -7))
+	case RangeMonth:
+		return Month(r.Start.AddDate(0, -1, 0))
+	case RangeQuarter:
+		return Quarter(r.Start.AddDate(0, -3, 0))
+	case RangeYear:
+		return Year(r.Start.AddDate(-1, 0, 0))
+	}
+	days := r.Days()
+	return Range{Kind: RangeDays, Start: r.Start.AddDate(0, 0, -days), End: r.Start.AddDate(0, 0, -1)}
+}
+
+// Next returns the period after the range, the counterpart of Previous.
+func (r Range) Next() Range {
+	switch r.Kind {
+	case RangeDay:
+		return Day(r.Start.AddDate(0, 0, 1))
+	case RangeWeek:
+		return Week(r.Start.AddDate(0, 0, 7))
+	case RangeMonth:
+		return Month(r.Start.AddDate(0, 1, 0))
+	case RangeQuarter:
+		return Quarter(r.Start.AddDate(0, 3, 0))
+	case RangeYear:
+		return Year(r.Start.AddDate(1, 0, 0))
+	}
+	days := r.Days()
+	return Range{Kind: RangeDays, Start: r.End.AddDate(0, 0, 1), End: r.End.AddDate(0, 0, days)}
+}
+
+// FirstDays returns the first n days of the range.
+func (r Range) FirstDays(n int) Range {
+	if n >= r.Days() {
+		return r
+	}
+	return Range{Kind: RangeDays, Start: r.Start, End: r.Start.AddDate(0, 0, max(n, 1)-1)}
+}
+
+// PreviousPeriod returns the period to compare the range with. While the
+// range is still in progress, only as many days of the previous period are
+// used as have passed, so a week that started on Monday is compared with
+// last Monday rather than all of last week.
+func PreviousPeriod(r Range, today time.Time) Range {
+	previous := r.Previous()
+	if elapsed := r.ElapsedDays(today); elapsed < r.Days() {
+		previous = previous.FirstDays(elapsed)
+	}
+	return previous
+}
+
+// ParseRange accepts a date (2025-11-15), ISO week (2025-W46), month
+// (2025-11), quarter (2025-Q4), year (2025) or span of dates
+// (2025-11-01..2025-11-15).
+func ParseRange(value string, today time.Time) (Range, error) {
+	value = strings.TrimSpace(value)
+
+	if from, to, ok := strings.Cut(value, ".."); ok {
+		start, err := ParseDate(from, today)
+		if err != nil {
+			return Range{}, err
+		}
+		end, err := ParseDate(to, today)
+		if err != nil {
+			return Range{}, err
+		}
+		return Between(start, end), nil
+	}
+
+	upper := strings.ToUpper(value)
+	switch {
+	case isoWeekRe.MatchString(upper):
+		return ParseWeek(value, today)
+	case quarterRe.MatchString(upper):
+		return ParseQuarter(value, today)
+	case len(value) == len("2006-01"):
+		return ParseMonth(value, today)
+	case len(value) == len("2006"):
+		return ParseYear(value, today)
+	}
+
+	date, err := ParseDate(value, today)
+	if err != nil {
+		return Range{}, fmt.Errorf("invalid range %q, expected a date, YYYY-Www, YYYY-MM, YYYY-Qn, YYYY or FROM..TO", value)
+	}
+	return Day(date), nil
+}
+
+// Change compares one figure of a report with the previous period.
+type Change struct {
+	Name     string
+	Current  float64
+	Previous float64
+	// Format renders a value of the figure, with a sign when asked to.
+	Format func(value float64, signed bool) string
+}
+
+func (c Change) Delta() float64 {
+	return c.Current - c.Previous
+}
+
+// Percent is the relative change, which is undefined when the previous
+// value is zero.
+func (c Change) Percent() (float64, bool) {
+	if c.Previous == 0 {
+		return 0, false
+	}
+	return c.Delta() / c.Previous * 100, true
+}
+
+// Direction is 1 for an increase, -1 for a decrease and 0 when unchanged
+// at the precision shown.
+func (c Change) Direction() int {
+	if c.Format(c.Current, false) == c.Format(c.Previous, false) {
+		return 0
+	}
+	if c.Delta() > 0 {
+		return 1
+	}
+	return -1
+}
+
+// Changes compares the headline figures with the previous period. It
+// returns nil when there is nothing to compare with.
+func (s *Stats) Changes() []Change {
+	if s.Previous == nil {
+		return nil
+	}
+	p := s.Previous
+
+	hours := func(value float64, signed bool) string {
+		return signedFormat("%.1fh", value, signed)
+	}
+	count := func(value float64, signed bool) string {
+		return signedFormat("%.0f", value, signed)
+	}
+	mins := func(value float64, signed bool) string {
+		return signedFormat("%.0fm", value, signed)
+	}
+	rating := func(value float64, signed bool) string {
+		return signedFormat("%.1f", value, signed)
+	}
+
+	return []Change{
+		{Name: "Deep work", Current: s.Total.Duration.Hours(), Previous: p.Total.Duration.Hours(), Format: hours},
+		{Name: "Sessions", Current: float64(s.Total.Sessions), Previous: float64(p.Total.Sessions), Format: count},
+		{Name: "Average session", Current: s.AvgSession().Minutes(), Previous: p.AvgSession().Minutes(), Format: mins},
+		{Name: "Average focus", Current: s.Total.AvgFocusQuality(), Previous: p.Total.AvgFocusQuality(), Format: rating},
+		{Name: "Active days", Current: float64(s.ActiveDays()), Previous: float64(p.ActiveDays()), Format: count},
+	}
+}
+
+func signedFormat(format string, value float64, signed bool) string {
+	if signed {
+		format = strings.Replace(format, "%", "%+", 1)
+		// Avoid printing "-0.0" for changes that round to nothing.
+		if math.Abs(value) < 0.05 {
+			value = 0
+		}
+	}
+	return fmt.Sprintf(format, value)
+}
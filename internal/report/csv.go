@@ -0,0 +1,46 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+var csvHeader = []string{"date", "start", "end", "minutes", "focus_quality", "milestone", "continued", "interruptions", "reflection"}
+
+// renderCSV writes one row per session, with pieces of sessions that cross
+// midnight on their own day's row.
+func renderCSV(w io.Writer, s *Stats) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, session := range s.Sessions {
+		focusQuality := ""
+		if session.FocusQuality > 0 {
+			focusQuality = strconv.Itoa(session.FocusQuality)
+		}
+
+		record := []string{
+			dayKey(session.Date),
+			formatTimestamp(session.Start),
+			formatTimestamp(session.End),
+			strconv.FormatFloat(minutes(session.Duration), 'f', -1, 64),
+			focusQuality,
+			session.Milestone,
+			strconv.FormatBool(session.Continued),
+			session.Interruptions,
+			session.Reflection,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
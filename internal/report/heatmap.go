@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const heatmapLabelWidth = 4
+
+// heatmapLevels are the upper bounds of each shade after the first, which
+// is reserved for days without deep work.
+var heatmapLevels = []struct {
+	label string
+	upTo  time.Duration
+}{
+	{"<1h", time.Hour},
+	{"1-2h", 2 * time.Hour},
+	{"2-3h", 3 * time.Hour},
+	{"3h+", math.MaxInt64},
+}
+
+var heatmapColors = []lipgloss.AdaptiveColor{
+	{Light: "#ebedf0", Dark: "#2d333b"},
+	{Light: "#9be9a8", Dark: "#0e4429"},
+	{Light: "#40c463", Dark: "#006d32"},
+	{Light: "#30a14e", Dark: "#26a641"},
+	{Light: "#216e39", Dark: "#39d353"},
+}
+
+var (
+	heatmapUnicodeShades = []string{"·", "░", "▒", "▓", "█"}
+	heatmapASCIIShades   = []string{".", "-", "+", "*", "#"}
+)
+
+// LastWeeks returns the range of the last n weeks, from the Monday n-1
+// weeks before today's week up to today.
+func LastWeeks(today time.Time, n int) Range {
+	return Between(Week(today).Start.AddDate(0, 0, -7*(n-1)), today)
+}
+
+// RenderHeatmap writes a calendar of the range with a column per week and a
+// row per weekday, each day shaded by its minutes of deep work.
+func RenderHeatmap(w io.Writer, s *Stats, opts RenderOptions) error {
+	width := opts.width()
+
+	if s.ElapsedDays() == 0 {
+		_, err := fmt.Fprintf(w, "No days of %s have passed yet.\n", s.Range.Title())
+		return err
+	}
+
+	byDay := make(map[string]time.Duration)
+	for _, day := range s.Days {
+		byDay[dayKey(day.Date)] = day.Duration
+	}
+
+	last := s.Range.End
+	if dayKey(s.Today) < dayKey(last) {
+		last = s.Today
+	}
+	first := Week(s.Range.Start).Start
+	weeks := daysBetween(first, Week(last).Start)/7 + 1
+
+	cellWidth := 2
+	if heatmapLabelWidth+weeks*cellWidth > width {
+		cellWidth = 1
+	}
+	truncated := false
+	if fits := (width - heatmapLabelWidth) / cellWidth; weeks > fits && fits > 0 {
+		first = first.AddDate(0, 0, 7*(weeks-fits))
+		weeks = fits
+		truncated = true
+	}
+
+	shades := heatmapUnicodeShades
+	if opts.ASCII {
+		shades = heatmapASCIIShades
+	}
+	cell := func(level int) string {
+		if !opts.Color {
+			return shades[level]
+		}
+		block := "■"
+		if opts.ASCII {
+			block = "#"
+		}
+		return lipgloss.NewStyle().Foreground(heatmapColors[level]).Render(block)
+	}
+
+	var b strings.Builder
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "  Deep Work Heatmap: %s\n", s.Range.Title())
+	fmt.Fprintln(&b)
+
+	b.WriteString(strings.Repeat(" ", heatmapLabelWidth))
+	b.WriteString(heatmapMonthLabels(first, weeks, cellWidth))
+	b.WriteString("\n")
+
+	for weekday := 0; weekday < 7; weekday++ {
+		label := ""
+		if weekday%2 == 0 && weekday < 6 {
+			label = first.AddDate(0, 0, weekday).Format("Mon")
+		}
+		b.WriteString(padRight(label, heatmapLabelWidth))
+
+		for week := 0; week < weeks; week++ {
+			date := first.AddDate(0, 0, week*7+weekday)
+			if !s.Range.Contains(date) || dayKey(date) > dayKey(last) {
+				b.WriteString(strings.Repeat(" ", cellWidth))
+				continue
+			}
+			b.WriteString(cell(heatmapLevel(byDay[dayKey(date)])))
+			b.WriteString(strings.Repeat(" ", cellWidth-1))
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintln(&b)
+	b.WriteString(strings.Repeat(" ", heatmapLabelWidth) + "Less ")
+	for level := range heatmapColors {
+		b.WriteString(cell(level) + " ")
+	}
+	b.WriteString("More   (")
+	labels := []string{"none"}
+	for _, level := range heatmapLevels {
+		labels = append(labels, level.label)
+	}
+	b.WriteString(strings.Join(labels, ", ") + ")\n")
+
+	fmt.Fprintf(&b, "%s%.1f hours on %d of %d days\n",
+		strings.Repeat(" ", heatmapLabelWidth),
+		s.Total.Duration.Hours(),
+		s.ActiveDays(),
+		s.ElapsedDays())
+	if truncated {
+		fmt.Fprintf(&b, "%sShowing the last %d weeks that fit the terminal.\n", strings.Repeat(" ", heatmapLabelWidth), weeks)
+	}
+	fmt.Fprintln(&b)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func heatmapLevel(duration time.Duration) int {
+	if duration <= 0 {
+		return 0
+	}
+	for i, level := range heatmapLevels {
+		if duration < level.upTo {
+			return i + 1
+		}
+	}
+	return len(heatmapLevels)
+}
+
+// heatmapMonthLabels labels the week column holding the 1st of each
+// month, and the first column, skipping labels that would overlap.
+func heatmapMonthLabels(first time.Time, weeks, cellWidth int) string {
+	line := []rune(strings.Repeat(" ", weeks*cellWidth+3))
+	next := 0
+	for week := 0; week < weeks; week++ {
+		monday := first.AddDate(0, 0, week*7)
+		sunday := monday.AddDate(0, 0, 6)
+
+		month := sunday
+		startsMonth := monday.Day() == 1 || monday.Month() != sunday.Month()
+		if !startsMonth {
+			if week > 0 {
+				continue
+			}
+			month = monday
+		}
+
+		column := week * cellWidth
+		if column < next {
+			continue
+		}
+		copy(line[column:], []rune(month.Format("Jan")))
+		next = column + 4
+	}
+	return strings.TrimRight(string(line), " ")
+}
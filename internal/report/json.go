@@ -0,0 +1,323 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"time"
+
+	"altum/internal/notes"
+)
+
+type jsonReport struct {
+	Range             jsonRange       `json:"range"`
+	Totals            jsonSummary     `json:"totals"`
+	AvgSessionMinutes float64         `json:"avg_session_minutes"`
+	ActiveDays        int             `json:"active_days"`
+	ElapsedDays       int             `json:"elapsed_days"`
+	ActiveDaysPercent float64         `json:"active_days_percent"`
+	BestDay           *jsonDay        `json:"best_day"`
+	LongestSessionDay *jsonDay        `json:"longest_session_day"`
+	Days              []jsonDay       `json:"days"`
+	TimeOfDay         *jsonTimeOfDay  `json:"time_of_day,omitempty"`
+	Comparison        *jsonComparison `json:"comparison,omitempty"`
+	Weekdays          []jsonWeekday   `json:"weekdays,omitempty"`
+	SessionLength     *jsonLength     `json:"session_length,omitempty"`
+	Interruptions     *jsonTerms      `json:"interruptions,omitempty"`
+	ReflectionThemes  *jsonTerms      `json:"reflection_themes,omitempty"`
+	Sessions          []jsonSession   `json:"sessions,omitempty"`
+}
+
+type jsonTerms struct {
+	Sessions int        `json:"sessions"`
+	Terms    []jsonTerm `json:"terms"`
+}
+
+type jsonTerm struct {
+	Term     string    `json:"term"`
+	Sessions int       `json:"sessions"`
+	Trend    string    `json:"trend"`
+	Daily    []float64 `json:"daily"`
+}
+
+type jsonComparison struct {
+	Range   jsonRange    `json:"range"`
+	Totals  jsonSummary  `json:"totals"`
+	Changes []jsonChange `json:"changes"`
+}
+
+type jsonChange struct {
+	Name     string   `json:"name"`
+	Current  float64  `json:"current"`
+	Previous float64  `json:"previous"`
+	Delta    float64  `json:"delta"`
+	Percent  *float64 `json:"percent"`
+}
+
+type jsonWeekday struct {
+	Weekday            string  `json:"weekday"`
+	Days               int     `json:"days"`
+	ActiveDays         int     `json:"active_days"`
+	ConsistencyPercent float64 `json:"consistency_percent"`
+	AvgMinutes         float64 `json:"avg_minutes"`
+	StdDevMinutes      float64 `json:"stddev_minutes"`
+	AvgSessions        float64 `json:"avg_sessions"`
+	AvgFocusQuality    float64 `json:"avg_focus_quality"`
+}
+
+type jsonLength struct {
+	Buckets              []jsonLengthBucket `json:"buckets"`
+	Correlation          *float64           `json:"correlation"`
+	SweetSpot            string             `json:"sweet_spot,omitempty"`
+	LongSessionMinutes   float64            `json:"long_session_minutes"`
+	LongAvgFocusQuality  float64            `json:"long_avg_focus_quality"`
+	ShortAvgFocusQuality float64            `json:"short_avg_focus_quality"`
+	LongSessionsWorse    bool               `json:"long_sessions_worse"`
+}
+
+type jsonLengthBucket struct {
+	Length          string  `json:"length"`
+	Sessions        int     `json:"sessions"`
+	RatedSessions   int     `json:"rated_sessions"`
+	AvgFocusQuality float64 `json:"avg_focus_quality"`
+}
+
+type jsonTimeOfDay struct {
+	Hours               []jsonHour `json:"hours"`
+	PeakWindow          string     `json:"peak_window"`
+	PeakMinutes         float64    `json:"peak_minutes"`
+	PeakSharePercent    float64    `json:"peak_share_percent"`
+	PeakAvgFocusQuality float64    `json:"peak_avg_focus_quality"`
+}
+
+type jsonHour struct {
+	Hour            int     `json:"hour"`
+	Minutes         float64 `json:"minutes"`
+	SessionsStarted int     `json:"sessions_started"`
+	AvgFocusQuality float64 `json:"avg_focus_quality"`
+}
+
+type jsonRange struct {
+	Kind  string `json:"kind"`
+	Title string `json:"title"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Days  int    `json:"days"`
+}
+
+type jsonSummary struct {
+	Sessions              int     `json:"sessions"`
+	Minutes               float64 `json:"minutes"`
+	Hours                 float64 `json:"hours"`
+	AvgFocusQuality       float64 `json:"avg_focus_quality"`
+	RatedSessions         int     `json:"rated_sessions"`
+	FocusQualityTotal     int     `json:"focus_quality_total"`
+	LongestSessionMinutes float64 `json:"longest_session_minutes"`
+}
+
+type jsonDay struct {
+	Date string `json:"date"`
+	jsonSummary
+}
+
+type jsonSession struct {
+	Date          string  `json:"date"`
+	Start         string  `json:"start,omitempty"`
+	End           string  `json:"end,omitempty"`
+	Minutes       float64 `json:"minutes"`
+	FocusQuality  int     `json:"focus_quality,omitempty"`
+	Milestone     string  `json:"milestone,omitempty"`
+	Interruptions string  `json:"interruptions,omitempty"`
+	Reflection    string  `json:"reflection,omitempty"`
+	Continued     bool    `json:"continued,omitempty"`
+}
+
+func renderJSON(w io.Writer, s *Stats, opts RenderOptions) error {
+	out := jsonReport{
+		Range:             newJSONRange(s.Range),
+		Totals:            newJSONSummary(s.Total),
+		AvgSessionMinutes: minutes(s.AvgSession()),
+		ActiveDays:        s.ActiveDays(),
+		ElapsedDays:       s.ElapsedDays(),
+		ActiveDaysPercent: round1(s.ActiveDaysPercent()),
+		Days:              []jsonDay{},
+	}
+
+	if day, ok := s.BestDay(); ok {
+		best := newJSONDay(day)
+		out.BestDay = &best
+	}
+	if day, ok := s.LongestSessionDay(); ok {
+		longest := newJSONDay(day)
+		out.LongestSessionDay = &longest
+	}
+	for _, day := range s.Days {
+		out.Days = append(out.Days, newJSONDay(day))
+	}
+	if t, ok := s.TimeOfDay(); ok {
+		out.TimeOfDay = newJSONTimeOfDay(t)
+	}
+	if weekdays, ok := s.Weekdays(); ok {
+		for _, w := range weekdays {
+			out.Weekdays = append(out.Weekdays, jsonWeekday{
+				Weekday:            w.Weekday.String(),
+				Days:               w.Days,
+				ActiveDays:         w.ActiveDays,
+				ConsistencyPercent: round1(w.Consistency()),
+				AvgMinutes:         minutes(w.AvgDuration()),
+				StdDevMinutes:      minutes(w.StdDev),
+				AvgSessions:        round1(w.AvgSessions()),
+				AvgFocusQuality:    round1(w.AvgFocusQuality()),
+			})
+		}
+	}
+	if l, ok := s.LengthInsights(); ok {
+		out.SessionLength = newJSONLength(l)
+	}
+	if t, ok := s.TextInsights(opts.Vocabulary); ok {
+		out.Interruptions = newJSONTerms(t.InterruptedSessions, t.Interruptions)
+		out.ReflectionThemes = newJSONTerms(t.ReflectedSessions, t.Themes)
+	}
+	if changes := s.Changes(); changes != nil {
+		out.Comparison = &jsonComparison{
+			Range:   newJSONRange(s.Previous.Range),
+			Totals:  newJSONSummary(s.Previous.Total),
+			Changes: []jsonChange{},
+		}
+		for _, change := range changes {
+			out.Comparison.Changes = append(out.Comparison.Changes, newJSONChange(change))
+		}
+	}
+	if opts.Sessions {
+		out.Sessions = []jsonSession{}
+		for _, session := range s.Sessions {
+			out.Sessions = append(out.Sessions, newJSONSession(session))
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+func newJSONRange(r Range) jsonRange {
+	return jsonRange{
+		Kind:  r.Kind.String(),
+		Title: r.Title(),
+		Start: dayKey(r.Start),
+		End:   dayKey(r.End),
+		Days:  r.Days(),
+	}
+}
+
+func newJSONChange(change Change) jsonChange {
+	out := jsonChange{
+		Name:     change.Name,
+		Current:  round1(change.Current),
+		Previous: round1(change.Previous),
+		Delta:    round1(change.Delta()),
+	}
+	if percent, ok := change.Percent(); ok {
+		percent = round1(percent)
+		out.Percent = &percent
+	}
+	return out
+}
+
+func newJSONSummary(summary notes.Summary) jsonSummary {
+	return jsonSummary{
+		Sessions:              summary.Sessions,
+		Minutes:               minutes(summary.Duration),
+		Hours:                 round1(summary.Duration.Hours()),
+		AvgFocusQuality:       round1(summary.AvgFocusQuality()),
+		RatedSessions:         summary.FocusQualityCount,
+		FocusQualityTotal:     summary.FocusQualityTotal,
+		LongestSessionMinutes: minutes(summary.LongestSession),
+	}
+}
+
+func newJSONDay(day DayStats) jsonDay {
+	return jsonDay{Date: dayKey(day.Date), jsonSummary: newJSONSummary(day.Summary)}
+}
+
+func newJSONLength(l LengthInsights) *jsonLength {
+	out := &jsonLength{
+		Buckets:              []jsonLengthBucket{},
+		LongSessionMinutes:   LongSession.Minutes(),
+		LongAvgFocusQuality:  round1(l.LongAvgFocusQuality),
+		ShortAvgFocusQuality: round1(l.ShortAvgFocusQuality),
+		LongSessionsWorse:    l.LongSessionsWorse,
+	}
+	if l.HasCorrelation {
+		correlation := math.Round(l.Correlation*100) / 100
+		out.Correlation = &correlation
+	}
+	if l.SweetSpot >= 0 {
+		out.SweetSpot = l.Buckets[l.SweetSpot].Label
+	}
+	for _, bucket := range l.Buckets {
+		out.Buckets = append(out.Buckets, jsonLengthBucket{
+			Length:          bucket.Label,
+			Sessions:        bucket.Sessions,
+			RatedSessions:   bucket.FocusQualityCount,
+			AvgFocusQuality: round1(bucket.AvgFocusQuality()),
+		})
+	}
+	return out
+}
+
+func newJSONTimeOfDay(t TimeOfDay) *jsonTimeOfDay {
+	out := &jsonTimeOfDay{
+		Hours:               []jsonHour{},
+		PeakWindow:          t.PeakWindow(),
+		PeakMinutes:         minutes(t.PeakDuration),
+		PeakSharePercent:    round1(t.PeakShare()),
+		PeakAvgFocusQuality: round1(t.PeakFocusQuality),
+	}
+	for _, hour := range t.Hours {
+		out.Hours = append(out.Hours, jsonHour{
+			Hour:            hour.Hour,
+			Minutes:         minutes(hour.Duration),
+			SessionsStarted: hour.Sessions,
+			AvgFocusQuality: round1(hour.AvgFocusQuality()),
+		})
+	}
+	return out
+}
+
+func newJSONSession(session notes.Session) jsonSession {
+	return jsonSession{
+		Date:          dayKey(session.Date),
+		Start:         formatTimestamp(session.Start),
+		End:           formatTimestamp(session.End),
+		Minutes:       minutes(session.Duration),
+		FocusQuality:  session.FocusQuality,
+		Milestone:     session.Milestone,
+		Interruptions: session.Interruptions,
+		Reflection:    session.Reflection,
+		Continued:     session.Continued,
+	}
+}
+
+func newJSONTerms(sessions int, terms []TermCount) *jsonTerms {
+	out := &jsonTerms{Sessions: sessions, Terms: []jsonTerm{}}
+	for _, term := range terms {
+		out.Terms = append(out.Terms, jsonTerm{
+			Term:     term.Term,
+			Sessions: term.Sessions,
+			Trend:    trendName(term.Trend()),
+			Daily:    term.Daily,
+		})
+	}
+	return out
+}
+
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// minRatedSessions is how many rated sessions length insights need.
+	minRatedSessions = 5
+	// minBucketSessions is how many rated sessions a length needs to be
+	// the sweet spot.
+	minBucketSessions = 3
+
+	// LongSession is where sessions count as long when checking whether
+	// they rate worse, and longSessionGap how much lower they must rate.
+	LongSession    = 90 * time.Minute
+	longSessionGap = 0.5
+)
+
+type LengthBucket struct {
+	Label             string
+	Sessions          int
+	FocusQualityTotal int
+	FocusQualityCount int
+}
+
+func (b LengthBucket) AvgFocusQuality() float64 {
+	if b.FocusQualityCount == 0 {
+		return 0
+	}
+	return float64(b.FocusQualityTotal) / float64(b.FocusQualityCount)
+}
+
+// LengthInsights relates how long sessions were to how they were rated.
+type LengthInsights struct {
+	Buckets []LengthBucket
+	// Correlation is Pearson's r between session length and focus
+	// quality, if the lengths or ratings vary at all.
+	Correlation    float64
+	HasCorrelation bool
+	// SweetSpot is the index of the bucket rated highest, or -1 if no
+	// bucket has enough rated sessions.
+	SweetSpot int
+
+	LongAvgFocusQuality  float64
+	ShortAvgFocusQuality float64
+	// LongSessionsWorse is set when sessions of LongSession or more rate
+	// clearly lower than shorter ones.
+	LongSessionsWorse bool
+}
+
+// LengthInsights analyses the rated sessions of the range, as logged. It reports false
+// if there are too few to draw conclusions from.
+func (s *Stats) LengthInsights() (LengthInsights, bool) {
+	l := LengthInsights{Buckets: make([]LengthBucket, len(lengthBuckets)), SweetSpot: -1}
+	for i, bucket := range lengthBuckets {
+		l.Buckets[i].Label = bucket.label
+	}
+
+	// Sessions split at midnight are judged by their whole length. The
+	// remainder of one started before the range isn't counted.
+	var lengths, ratings []float64
+	var long, short LengthBucket
+	for _, session := range s.LoggedSessions() {
+		if session.Continued {
+			continue
+		}
+		bucket := &l.Buckets[lengthBucket(session.Duration)]
+		bucket.Sessions++
+		if session.FocusQuality <= 0 {
+			continue
+		}
+		bucket.FocusQualityTotal += session.FocusQuality
+		bucket.FocusQualityCount++
+
+		lengths = append(lengths, session.Duration.Minutes())
+		ratings = append(ratings, float64(session.FocusQuality))
+
+		group := &short
+		if session.Duration >= LongSession {
+			group = &long
+		}
+		group.FocusQualityTotal += session.FocusQuality
+		group.FocusQualityCount++
+	}
+	if len(ratings) < minRatedSessions {
+		return LengthInsights{}, false
+	}
+
+	l.Correlation, l.HasCorrelation = pearson(lengths, ratings)
+
+	for i, bucket := range l.Buckets {
+		if bucket.FocusQualityCount < minBucketSessions {
+			continue
+		}
+		if l.SweetSpot < 0 || bucket.AvgFocusQuality() > l.Buckets[l.SweetSpot].AvgFocusQuality() {
+			l.SweetSpot = i
+		}
+	}
+
+	l.LongAvgFocusQuality = long.AvgFocusQuality()
+	l.ShortAvgFocusQuality = short.AvgFocusQuality()
+	l.LongSessionsWorse = long.FocusQualityCount >= minBucketSessions &&
+		short.FocusQualityCount >= minBucketSessions &&
+		l.ShortAvgFocusQuality-l.LongAvgFocusQuality >= longSessionGap
+
+	return l, true
+}
+
+// CorrelationStrength describes the correlation in words.
+func (l LengthInsights) CorrelationStrength() string {
+	r := math.Abs(l.Correlation)
+	switch {
+	case !l.HasCorrelation || r < 0.1:
+		return "no"
+	case r < 0.3:
+		return "a weak"
+	case r < 0.5:
+		return "a moderate"
+	}
+	return "a strong"
+}
+
+func pearson(xs, ys []float64) (float64, bool) {
+	n := float64(len(xs))
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(varX*varY), true
+}
@@ -0,0 +1,142 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"altum/internal/notes"
+)
+
+// syntheticLengths are the lengths of the sessions logged each day.
+var syntheticLengths = []time.Duration{45 * time.Minute, 60 * time.Minute, 75 * time.Minute}
+
+// syntheticRating is the focus quality of the i-th session on date.
+func syntheticRating(date time.Time, i int) int {
+	return 3 + (date.Day()+i)%3
+}
+
+// syntheticVault writes a daily note with three sessions for every day of
+// the ten years up to end, the way Altum logs them.
+func syntheticVault(b *testing.B, end time.Time) notes.Config {
+	b.Helper()
+
+	c := notes.Config{
+		FolderPath: filepath.Join(b.TempDir(), "Daily"),
+		DateFormat: notes.ParseDateFormat("YYYY-MM-DD"),
+		CacheDir:   b.TempDir(),
+		Location:   time.UTC,
+	}
+	if err := os.MkdirAll(c.FolderPath, 0755); err != nil {
+		b.Fatal(err)
+	}
+
+	for date := end.AddDate(-10, 0, 1); !date.After(end); date = date.AddDate(0, 0, 1) {
+		content := "# " + c.NoteTitle(date) + "\n"
+		var sessions []notes.Session
+		for i, hour := range []int{9, 13, 16} {
+			start := date.Add(time.Duration(hour) * time.Hour)
+			entry := notes.Entry{
+				Start:         start,
+				End:           start.Add(syntheticLengths[i]),
+				Duration:      syntheticLengths[i],
+				Milestone:     fmt.Sprintf("Shipped part %d of the parser", i+1),
+				FocusQuality:  strconv.Itoa(syntheticRating(date, i)),
+				Interruptions: "slack pings, phone",
+				Reflection:    "Clear goal helped, phone away next time",
+			}
+
+			var err error
+			content, _, err = c.InsertSession(content, date, entry)
+			if err != nil {
+				b.Fatal(err)
+			}
+			sessions = append(sessions, notes.Session{Date: date, Start: entry.Start, End: entry.End, Duration: entry.Duration, FocusQuality: syntheticRating(date, i)})
+		}
+		content = notes.SetProperties(content, notes.Summarize(sessions).Properties())
+
+		if err := os.WriteFile(c.NotePath(date), []byte(content), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return c
+}
+
+// syntheticTotal is the summary syntheticVault's notes should give for r.
+func syntheticTotal(r Range) notes.Summary {
+	var total notes.Summary
+	for _, date := range r.Dates() {
+		for i, length := range syntheticLengths {
+			total.Sessions++
+			total.Duration += length
+			total.FocusQualityTotal += syntheticRating(date, i)
+			total.FocusQualityCount++
+			total.LongestSession = max(total.LongestSession, length)
+		}
+	}
+	return total
+}
+
+// BenchmarkReport measures loading and rendering a report on a ten-year
+// vault, with the index rebuilt on every run (cold) and reused (warm).
+func BenchmarkReport(b *testing.B) {
+	end := time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)
+	c := syntheticVault(b, end)
+
+	ranges := []struct {
+		name string
+		r    Range
+	}{
+		{"year", Year(end)},
+		{"all", Between(end.AddDate(-10, 0, 1), end)},
+	}
+
+	for _, cache := range []string{"cold", "warm"} {
+		for _, rng := range ranges {
+			for _, fast := range []bool{false, true} {
+				name := cache + "/" + rng.name
+				if fast {
+					name += "/fast"
+				}
+
+				b.Run(name, func(b *testing.B) {
+					// Loading once up front checks the vault parses as
+					// written, and warms the index.
+					stats, _, err := Load(c, rng.r, fast)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if want := syntheticTotal(rng.r); stats.Total != want {
+						b.Fatalf("Load() total = %+v, want %+v", stats.Total, want)
+					}
+					b.ResetTimer()
+
+					for range b.N {
+						if cache == "cold" {
+							b.StopTimer()
+							if err := c.ClearIndex(); err != nil {
+								b.Fatal(err)
+							}
+							b.StartTimer()
+						}
+
+						stats, warnings, err := Load(c, rng.r, fast)
+						if err != nil || len(warnings) > 0 {
+							b.Fatal(err, warnings)
+						}
+						if err := Render(io.Discard, FormatText, stats, RenderOptions{Width: 80}); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,220 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+func renderMarkdown(w io.Writer, s *Stats, opts RenderOptions) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Deep Work Report: %s\n\n", s.Range.Title())
+
+	if s.Empty() {
+		fmt.Fprintln(&b, "No sessions found.")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	fmt.Fprintln(&b, "| Metric | Value |")
+	fmt.Fprintln(&b, "| --- | --- |")
+	fmt.Fprintf(&b, "| Sessions | %d |\n", s.Total.Sessions)
+	fmt.Fprintf(&b, "| Deep work | %.1f hours (%d minutes) |\n", s.Total.Duration.Hours(), int(s.Total.Duration.Minutes()))
+	fmt.Fprintf(&b, "| Average session | %.0f minutes |\n", s.AvgSession().Minutes())
+	if s.Total.FocusQualityCount > 0 {
+		fmt.Fprintf(&b, "| Average rating | %.1f / 5 |\n", s.Total.AvgFocusQuality())
+	}
+	if s.Range.Kind != RangeDay {
+		bestDay, _ := s.BestDay()
+		fmt.Fprintf(&b, "| Best day | %s (%.1f hours) |\n", bestDay.Date.Format("Mon, Jan 2"), bestDay.Duration.Hours())
+		longestDay, _ := s.LongestSessionDay()
+		fmt.Fprintf(&b, "| Longest session | %d minutes (%s) |\n", int(longestDay.LongestSession.Minutes()), longestDay.Date.Format("Jan 2"))
+		if days := s.ElapsedDays(); days > 0 {
+			fmt.Fprintf(&b, "| Days with deep work | %d / %d (%.0f%%) |\n", s.ActiveDays(), days, s.ActiveDaysPercent())
+		}
+
+		if changes := s.Changes(); changes != nil {
+			fmt.Fprintln(&b)
+			fmt.Fprintf(&b, "## Compared with %s\n", s.Previous.Range.Title())
+			fmt.Fprintln(&b)
+			writeMarkdownChanges(&b, changes)
+		}
+
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "## Top Days")
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "| Day | Hours | Sessions | Rating |")
+		fmt.Fprintln(&b, "| --- | --- | --- | --- |")
+		for _, day := range s.TopDays(5) {
+			fmt.Fprintf(&b, "| %s | %.1f | %d | %s |\n",
+				day.Date.Format("Mon, Jan 2"),
+				day.Duration.Hours(),
+				day.Sessions,
+				markdownRating(day.AvgFocusQuality()))
+		}
+
+		if days := s.Milestones(""); len(days) > 0 {
+			fmt.Fprintln(&b)
+			fmt.Fprintln(&b, "## Milestones")
+			for _, day := range days {
+				fmt.Fprintln(&b)
+				fmt.Fprintf(&b, "### %s\n", day.Date.Format("Mon, Jan 2"))
+				fmt.Fprintln(&b)
+				writeMarkdownChecklist(&b, day.Sessions)
+			}
+		}
+	}
+
+	if s.FastDays > 0 {
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "_%s_\n", fastNote)
+	}
+
+	if t, ok := s.TimeOfDay(); ok && s.Range.Kind != RangeDay {
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "## Time of Day")
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "Most productive window: **%s** (%.0f%% of deep work", t.PeakWindow(), t.PeakShare())
+		if t.PeakRatedSessions > 0 {
+			fmt.Fprintf(&b, ", average focus %.1f", t.PeakFocusQuality)
+		}
+		fmt.Fprintln(&b, ")")
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "| Hour | Hours | Sessions started | Focus |")
+		fmt.Fprintln(&b, "| --- | --- | --- | --- |")
+		for _, hour := range t.ActiveHours() {
+			focus := ""
+			if hour.FocusQualityCount > 0 {
+				focus = fmt.Sprintf("%.1f", hour.AvgFocusQuality())
+			}
+			fmt.Fprintf(&b, "| %s | %.1f | %d | %s |\n", formatHour(hour.Hour), hour.Duration.Hours(), hour.Sessions, focus)
+		}
+	}
+
+	if weekdays, ok := s.Weekdays(); ok {
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "## By Weekday")
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "| Weekday | Avg hours | Avg sessions | Focus | Active | Spread |")
+		fmt.Fprintln(&b, "| --- | --- | --- | --- | --- | --- |")
+		for _, w := range weekdays {
+			focus := ""
+			if w.FocusQualityCount > 0 {
+				focus = fmt.Sprintf("%.1f", w.AvgFocusQuality())
+			}
+			fmt.Fprintf(&b, "| %s | %.1f | %.1f | %s | %d/%d | ±%.0fm |\n",
+				w.Weekday, w.AvgDuration().Hours(), w.AvgSessions(), focus, w.ActiveDays, w.Days, w.StdDev.Minutes())
+		}
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "Strongest: **%s** · Weakest: **%s**\n", StrongestWeekday(weekdays).Weekday, WeakestWeekday(weekdays).Weekday)
+	}
+
+	if l, ok := s.LengthInsights(); ok && s.Range.Kind != RangeDay {
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "## Session Length and Focus")
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "| Length | Sessions | Focus |")
+		fmt.Fprintln(&b, "| --- | --- | --- |")
+		for _, bucket := range l.Buckets {
+			focus := ""
+			if bucket.FocusQualityCount > 0 {
+				focus = fmt.Sprintf("%.1f", bucket.AvgFocusQuality())
+			}
+			fmt.Fprintf(&b, "| %s | %d | %s |\n", bucket.Label, bucket.Sessions, focus)
+		}
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "- Length and focus show %s correlation", l.CorrelationStrength())
+		if l.HasCorrelation {
+			fmt.Fprintf(&b, " (r = %.2f)", l.Correlation)
+		}
+		fmt.Fprintln(&b)
+		if l.SweetSpot >= 0 {
+			fmt.Fprintf(&b, "- Sweet spot: **%s**\n", l.Buckets[l.SweetSpot].Label)
+		}
+		if l.LongSessionsWorse {
+			fmt.Fprintf(&b, "- Sessions of %.0fm or more rate %.1f lower than shorter ones\n",
+				LongSession.Minutes(), l.ShortAvgFocusQuality-l.LongAvgFocusQuality)
+		}
+	}
+
+	if t, ok := s.TextInsights(opts.Vocabulary); ok && s.Range.Kind != RangeDay {
+		if len(t.Interruptions) > 0 {
+			fmt.Fprintln(&b)
+			fmt.Fprintln(&b, "## Recurring Interruptions")
+			fmt.Fprintln(&b)
+			fmt.Fprintf(&b, "%d of %d sessions were interrupted.\n", t.InterruptedSessions, t.Sessions)
+			fmt.Fprintln(&b)
+			fmt.Fprintln(&b, "| Source | Sessions | Trend |")
+			fmt.Fprintln(&b, "| --- | --- | --- |")
+			for _, term := range t.Interruptions {
+				fmt.Fprintf(&b, "| %s | %d | %s |\n", escapeMarkdownCell(term.Term), term.Sessions, trendName(term.Trend()))
+			}
+		}
+		if len(t.Themes) > 0 {
+			fmt.Fprintln(&b)
+			fmt.Fprintln(&b, "## Reflection Themes")
+			fmt.Fprintln(&b)
+			for _, theme := range t.Themes {
+				fmt.Fprintf(&b, "- %s (%d sessions)\n", theme.Term, theme.Sessions)
+			}
+		}
+	}
+
+	if len(s.Sessions) > 0 {
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "## Sessions")
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "| Date | Time | Minutes | Rating | Milestone |")
+		fmt.Fprintln(&b, "| --- | --- | --- | --- | --- |")
+		for _, session := range s.Sessions {
+			timeRange := ""
+			if !session.Start.IsZero() && !session.End.IsZero() {
+				timeRange = session.Start.Format("15:04") + " - " + session.End.Format("15:04")
+			}
+			rating := markdownRating(float64(session.FocusQuality))
+			if session.Continued {
+				rating = "(continued)"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %d | %s | %s |\n",
+				session.Date.Format("Jan 2"),
+				timeRange,
+				int(session.Duration.Minutes()),
+				rating,
+				escapeMarkdownCell(session.Milestone))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownChanges(b *strings.Builder, changes []Change) {
+	fmt.Fprintln(b, "| Metric | This period | Previous | Change |")
+	fmt.Fprintln(b, "| --- | --- | --- | --- |")
+	for _, change := range changes {
+		delta := change.Format(change.Delta(), true)
+		if percent, ok := change.Percent(); ok {
+			delta += fmt.Sprintf(" (%+.0f%%)", percent)
+		}
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n",
+			change.Name,
+			change.Format(change.Current, false),
+			change.Format(change.Previous, false),
+			delta)
+	}
+}
+
+func markdownRating(rating float64) string {
+	if rating == 0 {
+		return ""
+	}
+	return RatingStars(rating)
+}
+
+func escapeMarkdownCell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
@@ -0,0 +1,241 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"altum/internal/notes"
+)
+
+// reportMilestones is how many of the latest milestones the full report
+// lists before pointing to the digest.
+const reportMilestones = 10
+
+// MilestoneDay holds the sessions with a milestone that started on a day.
+type MilestoneDay struct {
+	Date     time.Time
+	Sessions []notes.Session
+}
+
+func (d MilestoneDay) Duration() time.Duration {
+	var total time.Duration
+	for _, session := range d.Sessions {
+		total += session.Duration
+	}
+	return total
+}
+
+// Milestones groups the sessions with a milestone by day, oldest first.
+// With a query, only milestones containing every word of it are kept,
+// ignoring case.
+func (s *Stats) Milestones(query string) []MilestoneDay {
+	words := strings.Fields(strings.ToLower(query))
+
+	var days []MilestoneDay
+	for _, session := range s.LoggedSessions() {
+		milestone := strings.ToLower(session.Milestone)
+		if session.Continued || strings.TrimSpace(milestone) == "" || !containsAll(milestone, words) {
+			continue
+		}
+		if len(days) == 0 || dayKey(days[len(days)-1].Date) != dayKey(session.Date) {
+			days = append(days, MilestoneDay{Date: session.Date})
+		}
+		days[len(days)-1].Sessions = append(days[len(days)-1].Sessions, session)
+	}
+	return days
+}
+
+func countMilestones(days []MilestoneDay) int {
+	count := 0
+	for _, day := range days {
+		count += len(day.Sessions)
+	}
+	return count
+}
+
+func containsAll(text string, words []string) bool {
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// RenderMilestones writes the digest of milestones matching query. Markdown
+// is a checklist ready to paste into stand-up or review notes.
+func RenderMilestones(w io.Writer, format string, s *Stats, opts RenderOptions, query string) error {
+	days := s.Milestones(query)
+
+	switch format {
+	case FormatJSON:
+		return renderMilestonesJSON(w, s, days, query)
+	case FormatCSV:
+		return renderMilestonesCSV(w, days)
+	case FormatMarkdown:
+		return renderMilestonesMarkdown(w, s, days, query)
+	case FormatText, "":
+		return renderMilestonesText(w, s, days, opts.charset(), query)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+func renderMilestonesText(w io.Writer, s *Stats, days []MilestoneDay, cs Charset, query string) error {
+	if len(days) == 0 {
+		_, err := fmt.Fprintf(w, "No milestones found for %s%s.\n", s.Range.Title(), matching(query))
+		return err
+	}
+
+	var b strings.Builder
+	writeTextHeader(&b, "Milestones: "+s.Range.Title(), cs)
+
+	for _, day := range days {
+		fmt.Fprintf(&b, "%s %s %.1f hours\n", day.Date.Format("Mon, Jan 2"), cs.Dash, day.Duration().Hours())
+		for _, session := range day.Sessions {
+			fmt.Fprintf(&b, "  - %s (%s)\n", session.Milestone, milestoneDetails(session, cs))
+		}
+		fmt.Fprintln(&b)
+	}
+
+	fmt.Fprintf(&b, "%d milestones on %d days%s\n", countMilestones(days), len(days), matching(query))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func renderMilestonesMarkdown(w io.Writer, s *Stats, days []MilestoneDay, query string) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Milestones: %s\n", s.Range.Title())
+	if len(days) == 0 {
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "No milestones found%s.\n", matching(query))
+	}
+	for _, day := range days {
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "## %s\n", day.Date.Format("Mon, Jan 2"))
+		fmt.Fprintln(&b)
+		writeMarkdownChecklist(&b, day.Sessions)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownChecklist(b *strings.Builder, sessions []notes.Session) {
+	for _, session := range sessions {
+		fmt.Fprintf(b, "- [x] %s (%s)\n", session.Milestone, milestoneDetails(session, UnicodeCharset))
+	}
+}
+
+func milestoneDetails(session notes.Session, cs Charset) string {
+	details := fmt.Sprintf("%d min", int(session.Duration.Minutes()))
+	if session.FocusQuality > 0 {
+		details += ", " + cs.Stars(float64(session.FocusQuality))
+	}
+	return details
+}
+
+func matching(query string) string {
+	if strings.TrimSpace(query) == "" {
+		return ""
+	}
+	return fmt.Sprintf(" matching %q", query)
+}
+
+type jsonMilestones struct {
+	Range jsonRange          `json:"range"`
+	Query string             `json:"query,omitempty"`
+	Days  []jsonMilestoneDay `json:"days"`
+}
+
+type jsonMilestoneDay struct {
+	Date       string        `json:"date"`
+	Minutes    float64       `json:"minutes"`
+	Milestones []jsonSession `json:"milestones"`
+}
+
+func renderMilestonesJSON(w io.Writer, s *Stats, days []MilestoneDay, query string) error {
+	out := jsonMilestones{
+		Range: newJSONRange(s.Range),
+		Query: query,
+		Days:  []jsonMilestoneDay{},
+	}
+	for _, day := range days {
+		jsonDay := jsonMilestoneDay{
+			Date:    dayKey(day.Date),
+			Minutes: minutes(day.Duration()),
+		}
+		for _, session := range day.Sessions {
+			jsonDay.Milestones = append(jsonDay.Milestones, newJSONSession(session))
+		}
+		out.Days = append(out.Days, jsonDay)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+func renderMilestonesCSV(w io.Writer, days []MilestoneDay) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"date", "start", "end", "minutes", "focus_quality", "milestone"}); err != nil {
+		return err
+	}
+
+	for _, day := range days {
+		for _, session := range day.Sessions {
+			focusQuality := ""
+			if session.FocusQuality > 0 {
+				focusQuality = strconv.Itoa(session.FocusQuality)
+			}
+			record := []string{
+				dayKey(session.Date),
+				formatTimestamp(session.Start),
+				formatTimestamp(session.End),
+				strconv.FormatFloat(minutes(session.Duration), 'f', -1, 64),
+				focusQuality,
+				session.Milestone,
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeMilestonesText lists the latest milestones in the full text report.
+func writeMilestonesText(b *strings.Builder, s *Stats, cs Charset) {
+	days := s.Milestones("")
+	total := countMilestones(days)
+	if total == 0 {
+		return
+	}
+
+	fmt.Fprintln(b, "Milestones:")
+	shown := 0
+	for i := len(days) - 1; i >= 0 && shown < reportMilestones; i-- {
+		for _, session := range days[i].Sessions {
+			if shown == reportMilestones {
+				break
+			}
+			fmt.Fprintf(b, "  %s  %s (%s)\n", days[i].Date.Format("Jan 2"), session.Milestone, milestoneDetails(session, cs))
+			shown++
+		}
+	}
+	if total > shown {
+		fmt.Fprintf(b, "  %s and %d more (see --milestones)\n", cs.Ellipsis, total-shown)
+	}
+	fmt.Fprintln(b)
+}
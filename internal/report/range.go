@@ -0,0 +1,243 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RangeKind int
+
+const (
+	RangeDays RangeKind = iota
+	RangeDay
+	RangeWeek
+	RangeMonth
+	RangeQuarter
+	RangeYear
+)
+
+func (k RangeKind) String() string {
+	switch k {
+	case RangeDay:
+		return "day"
+	case RangeWeek:
+		return "week"
+	case RangeMonth:
+		return "month"
+	case RangeQuarter:
+		return "quarter"
+	case RangeYear:
+		return "year"
+	}
+	return "days"
+}
+
+// Range is an inclusive span of logical days. Start and End are midnight of
+// the first and last day.
+type Range struct {
+	Kind  RangeKind
+	Start time.Time
+	End   time.Time
+}
+
+var (
+	isoWeekRe = regexp.MustCompile(`^(\d{4})-?W(\d{1,2})$`)
+	quarterRe = regexp.MustCompile(`^(\d{4})-?Q([1-4])$`)
+)
+
+func LastDays(today time.Time, days int) Range {
+	if days < 1 {
+		days = 1
+	}
+	return Range{Kind: RangeDays, Start: today.AddDate(0, 0, -days+1), End: today}
+}
+
+func Between(from, to time.Time) Range {
+	if to.Before(from) {
+		from, to = to, from
+	}
+	return Range{Kind: RangeDays, Start: from, End: to}
+}
+
+func Day(date time.Time) Range {
+	return Range{Kind: RangeDay, Start: date, End: date}
+}
+
+// Week returns the ISO week (Monday to Sunday) containing date.
+func Week(date time.Time) Range {
+	monday := date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+	return Range{Kind: RangeWeek, Start: monday, End: monday.AddDate(0, 0, 6)}
+}
+
+func Month(date time.Time) Range {
+	start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	return Range{Kind: RangeMonth, Start: start, End: start.AddDate(0, 1, -1)}
+}
+
+func Quarter(date time.Time) Range {
+	month := time.Month((int(date.Month())-1)/3*3 + 1)
+	start := time.Date(date.Year(), month, 1, 0, 0, 0, 0, date.Location())
+	return Range{Kind: RangeQuarter, Start: start, End: start.AddDate(0, 3, -1)}
+}
+
+func Year(date time.Time) Range {
+	start := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, date.Location())
+	return Range{Kind: RangeYear, Start: start, End: start.AddDate(1, 0, -1)}
+}
+
+// ParseWeek accepts "this", "last" or an ISO week such as 2025-W46.
+func ParseWeek(value string, today time.Time) (Range, error) {
+	switch strings.ToLower(value) {
+	case "", "this":
+		return Week(today), nil
+	case "last":
+		return Week(today.AddDate(0, 0, -7)), nil
+	}
+
+	matches := isoWeekRe.FindStringSubmatch(strings.ToUpper(value))
+	if matches == nil {
+		return Range{}, fmt.Errorf("invalid week %q, expected this, last or YYYY-Www", value)
+	}
+	year, _ := strconv.Atoi(matches[1])
+	week, _ := strconv.Atoi(matches[2])
+
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, today.Location())
+	r := Week(jan4.AddDate(0, 0, (week-1)*7))
+	if isoYear, isoWeek := r.Start.ISOWeek(); isoYear != year || isoWeek != week {
+		return Range{}, fmt.Errorf("%d has no week %d", year, week)
+	}
+	return r, nil
+}
+
+// ParseMonth accepts "this", "last" or a month such as 2025-11.
+func ParseMonth(value string, today time.Time) (Range, error) {
+	switch strings.ToLower(value) {
+	case "", "this":
+		return Month(today), nil
+	case "last":
+		return Month(Month(today).Start.AddDate(0, -1, 0)), nil
+	}
+
+	date, err := time.ParseInLocation("2006-01", value, today.Location())
+	if err != nil {
+		return Range{}, fmt.Errorf("invalid month %q, expected this, last or YYYY-MM", value)
+	}
+	return Month(date), nil
+}
+
+// ParseQuarter accepts "this", "last" or a quarter such as 2025-Q4.
+func ParseQuarter(value string, today time.Time) (Range, error) {
+	switch strings.ToLower(value) {
+	case "", "this":
+		return Quarter(today), nil
+	case "last":
+		return Quarter(Quarter(today).Start.AddDate(0, -3, 0)), nil
+	}
+
+	matches := quarterRe.FindStringSubmatch(strings.ToUpper(value))
+	if matches == nil {
+		return Range{}, fmt.Errorf("invalid quarter %q, expected this, last or YYYY-Qn", value)
+	}
+	year, _ := strconv.Atoi(matches[1])
+	quarter, _ := strconv.Atoi(matches[2])
+	return Quarter(time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, today.Location())), nil
+}
+
+// ParseYear accepts "this", "last" or a year such as 2025.
+func ParseYear(value string, today time.Time) (Range, error) {
+	switch strings.ToLower(value) {
+	case "", "this":
+		return Year(today), nil
+	case "last":
+		return Year(today.AddDate(-1, 0, 0)), nil
+	}
+
+	year, err := strconv.Atoi(value)
+	if err != nil || year < 1 {
+		return Range{}, fmt.Errorf("invalid year %q, expected this, last or YYYY", value)
+	}
+	return Year(time.Date(year, time.January, 1, 0, 0, 0, 0, today.Location())), nil
+}
+
+// ParseDate accepts "today", "yesterday" or a date such as 2025-11-15.
+func ParseDate(value string, today time.Time) (time.Time, error) {
+	switch strings.ToLower(value) {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, today.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return date, nil
+}
+
+func (r Range) Contains(date time.Time) bool {
+	key := dayKey(date)
+	return key >= dayKey(r.Start) && key <= dayKey(r.End)
+}
+
+// Days returns the number of days in the range.
+func (r Range) Days() int {
+	return daysBetween(r.Start, r.End) + 1
+}
+
+// ElapsedDays returns the number of days in the range up to and including
+// today, so a report on the current month isn't diluted by days to come.
+func (r Range) ElapsedDays(today time.Time) int {
+	if dayKey(today) < dayKey(r.Start) {
+		return 0
+	}
+	if dayKey(today) >= dayKey(r.End) {
+		return r.Days()
+	}
+	return daysBetween(r.Start, today) + 1
+}
+
+// Dates returns every day in the range.
+func (r Range) Dates() []time.Time {
+	var dates []time.Time
+	for date := r.Start; dayKey(date) <= dayKey(r.End); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+func (r Range) Title() string {
+	switch r.Kind {
+	case RangeDay:
+		return r.Start.Format("Monday, Jan 2, 2006")
+	case RangeWeek:
+		year, week := r.Start.ISOWeek()
+		return fmt.Sprintf("Week %d, %d (%s - %s)", week, year, r.Start.Format("Jan 2"), r.End.Format("Jan 2"))
+	case RangeMonth:
+		return r.Start.Format("January 2006")
+	case RangeQuarter:
+		return fmt.Sprintf("Q%d %d", (int(r.Start.Month())-1)/3+1, r.Start.Year())
+	case RangeYear:
+		return strconv.Itoa(r.Start.Year())
+	}
+	if dayKey(r.Start) == dayKey(r.End) {
+		return r.Start.Format("Jan 2, 2006")
+	}
+	return fmt.Sprintf("%s - %s", r.Start.Format("Jan 2, 2006"), r.End.Format("Jan 2, 2006"))
+}
+
+func dayKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+func daysBetween(from, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatCSV      = "csv"
+	FormatMarkdown = "markdown"
+)
+
+var Formats = []string{FormatText, FormatJSON, FormatCSV, FormatMarkdown}
+
+type RenderOptions struct {
+	// Sessions adds a row per session to the JSON output. CSV always has
+	// them, and Markdown lists them whenever they were parsed.
+	Sessions bool
+	// Width is the terminal width text charts are fitted to.
+	Width int
+	// ASCII draws text reports with plain ASCII characters only.
+	ASCII bool
+	// Color shades the heatmap with colours rather than characters.
+	Color bool
+	// Vocabulary is used to find recurring interruptions and reflection
+	// themes.
+	Vocabulary Vocabulary
+}
+
+// ParseFormat checks an output format name, accepting "md" for Markdown.
+func ParseFormat(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "", FormatText:
+		return FormatText, nil
+	case "md":
+		return FormatMarkdown, nil
+	case FormatJSON, FormatCSV, FormatMarkdown:
+		return name, nil
+	}
+	return "", fmt.Errorf("unknown report format %q (use %s)", name, strings.Join(Formats, ", "))
+}
+
+// fastNote stands in for the sections a report read from frontmatter totals
+// has to leave out.
+const fastNote = "Milestones, time of day, session lengths and interruptions need every\nsession; run without --fast to include them."
+
+// NeedsSessions reports whether a format shows individual sessions, which
+// can't be read from frontmatter totals.
+func NeedsSessions(format string, opts RenderOptions) bool {
+	switch format {
+	case FormatCSV:
+		return true
+	case FormatJSON:
+		return opts.Sessions
+	}
+	return false
+}
+
+func Render(w io.Writer, format string, s *Stats, opts RenderOptions) error {
+	switch format {
+	case FormatJSON:
+		return renderJSON(w, s, opts)
+	case FormatCSV:
+		return renderCSV(w, s)
+	case FormatMarkdown:
+		return renderMarkdown(w, s, opts)
+	case FormatText, "":
+		return renderText(w, s, opts)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+func minutes(d time.Duration) float64 {
+	return round1(d.Minutes())
+}
+
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
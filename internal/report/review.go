@@ -0,0 +1,111 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"altum/internal/notes"
+)
+
+// RenderReview writes the body of a weekly or monthly review note: the
+// period's totals, its top days and a digest of the milestones and
+// reflections logged in it. Days link to their daily notes. Headings are
+// level 3 so the review can sit under a level 2 section.
+func RenderReview(w io.Writer, s *Stats, c notes.Config) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "*%s · updated by Altum on %s*\n", s.Range.Title(), s.Today.Format("2006-01-02"))
+
+	if s.Empty() {
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "No sessions logged.")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "### Summary")
+	fmt.Fprintln(&b)
+	if changes := s.Changes(); changes != nil {
+		writeMarkdownChanges(&b, changes)
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "Compared with %s.\n", s.Previous.Range.Title())
+	} else {
+		fmt.Fprintln(&b, "| Metric | Value |")
+		fmt.Fprintln(&b, "| --- | --- |")
+		fmt.Fprintf(&b, "| Deep work | %.1f hours |\n", s.Total.Duration.Hours())
+		fmt.Fprintf(&b, "| Sessions | %d |\n", s.Total.Sessions)
+		fmt.Fprintf(&b, "| Average session | %.0f minutes |\n", s.AvgSession().Minutes())
+		if s.Total.FocusQualityCount > 0 {
+			fmt.Fprintf(&b, "| Average focus | %.1f / 5 |\n", s.Total.AvgFocusQuality())
+		}
+		fmt.Fprintf(&b, "| Active days | %d / %d |\n", s.ActiveDays(), s.ElapsedDays())
+	}
+
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "### Top Days")
+	fmt.Fprintln(&b)
+	for _, day := range s.TopDays(5) {
+		fmt.Fprintf(&b, "- %s · %.1fh · %s", dailyNoteLink(c, day.Date), day.Duration.Hours(), pluralize(day.Sessions, "session"))
+		if day.FocusQualityCount > 0 {
+			fmt.Fprintf(&b, " · %s", RatingStars(day.AvgFocusQuality()))
+		}
+		fmt.Fprintln(&b)
+	}
+
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "### Milestones")
+	fmt.Fprintln(&b)
+	sessions := s.LoggedSessions()
+	milestones := 0
+	for _, session := range sessions {
+		if session.Continued || strings.TrimSpace(session.Milestone) == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "- %s %s · %dm", dailyNoteLink(c, session.Date), session.Milestone, int(session.Duration.Minutes()))
+		if session.FocusQuality > 0 {
+			fmt.Fprintf(&b, " · %s", RatingStars(float64(session.FocusQuality)))
+		}
+		fmt.Fprintln(&b)
+		milestones++
+	}
+	if milestones == 0 {
+		fmt.Fprintln(&b, "No milestones logged.")
+	}
+
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "### Reflections")
+	fmt.Fprintln(&b)
+	reflections := 0
+	for _, session := range sessions {
+		if session.Continued || strings.TrimSpace(session.Reflection) == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "- %s %s\n", dailyNoteLink(c, session.Date), session.Reflection)
+		reflections++
+	}
+	if reflections == 0 {
+		fmt.Fprintln(&b, "No reflections logged.")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dailyNoteLink is an Obsidian wikilink to the daily note of date, shown as
+// the weekday and date.
+func dailyNoteLink(c notes.Config, date time.Time) string {
+	return fmt.Sprintf("[[%s|%s]]", c.NoteName(date), date.Format("Mon, Jan 2"))
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
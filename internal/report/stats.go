@@ -0,0 +1,234 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"sort"
+	"time"
+
+	"altum/internal/notes"
+)
+
+// DayStats holds the totals of one logical day.
+type DayStats struct {
+	Date time.Time
+	notes.Summary
+}
+
+// Stats is everything a report shows for a range, computed once so that
+// each output format renders the same numbers.
+type Stats struct {
+	Range Range
+	Today time.Time
+
+	// Days lists the days with deep work in date order.
+	Days  []DayStats
+	Total notes.Summary
+
+	// Sessions lists the sessions worked in the range, with sessions that
+	// cross midnight split into a piece per day. It is empty when the totals
+	// were read from frontmatter.
+	Sessions []notes.Session
+
+	// FastDays counts the days whose totals were read from frontmatter,
+	// which the sections built from sessions leave out.
+	FastDays int
+
+	// Previous holds the stats of the period the report is compared with.
+	Previous *Stats
+}
+
+// Load reads the daily notes overlapping the range and computes its stats.
+// With fast set, daily totals are taken from the frontmatter Altum writes
+// where available. Notes that fail to parse are skipped and returned as
+// warnings.
+func Load(c notes.Config, r Range, fast bool) (*Stats, []error, error) {
+	// Sessions that cross midnight can spill into the day before or after
+	// their note.
+	files, warnings, err := c.ScanNotes(func(file notes.NoteFile) bool {
+		return r.Contains(file.Date) || r.Contains(file.Date.AddDate(0, 0, 1)) || r.Contains(file.Date.AddDate(0, 0, -1))
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sessions []notes.Session
+	fastDays := 0
+	days := make(map[string]*DayStats)
+	addToDay := func(date time.Time, summary notes.Summary) {
+		key := dayKey(date)
+		if days[key] == nil {
+			days[key] = &DayStats{Date: date}
+		}
+		days[key].Add(summary)
+	}
+
+	for _, file := range files {
+		if fast && r.Contains(file.Date) {
+			if summary, ok := notes.SummaryFromProperties(file.Properties); ok {
+				addToDay(file.Date, summary)
+				fastDays++
+				continue
+			}
+		}
+
+		for _, session := range file.Sessions {
+			for _, piece := range c.SplitByDay(session) {
+				if r.Contains(piece.Date) {
+					sessions = append(sessions, piece)
+					addToDay(piece.Date, notes.Summarize([]notes.Session{piece}))
+				}
+			}
+		}
+	}
+
+	var dayList []DayStats
+	for _, day := range days {
+		if day.Duration > 0 || day.Sessions > 0 {
+			dayList = append(dayList, *day)
+		}
+	}
+
+	stats := Compute(r, c.Today(), dayList, sessions)
+	stats.FastDays = fastDays
+	return stats, warnings, nil
+}
+
+// Compute builds the stats of a range from its days and sessions.
+func Compute(r Range, today time.Time, days []DayStats, sessions []notes.Session) *Stats {
+	s := &Stats{
+		Range:    r,
+		Today:    today,
+		Days:     append([]DayStats(nil), days...),
+		Sessions: append([]notes.Session(nil), sessions...),
+	}
+
+	sort.Slice(s.Days, func(i, j int) bool {
+		return s.Days[i].Date.Before(s.Days[j].Date)
+	})
+	sort.SliceStable(s.Sessions, func(i, j int) bool {
+		if !s.Sessions[i].Date.Equal(s.Sessions[j].Date) {
+			return s.Sessions[i].Date.Before(s.Sessions[j].Date)
+		}
+		return s.Sessions[i].Start.Before(s.Sessions[j].Start)
+	})
+
+	for _, day := range s.Days {
+		s.Total.Add(day.Summary)
+	}
+
+	return s
+}
+
+func (s *Stats) Empty() bool {
+	return len(s.Days) == 0
+}
+
+func (s *Stats) AvgSession() time.Duration {
+	if s.Total.Sessions == 0 {
+		return 0
+	}
+	return s.Total.Duration / time.Duration(s.Total.Sessions)
+}
+
+// ActiveDays is the number of days with deep work.
+func (s *Stats) ActiveDays() int {
+	return len(s.Days)
+}
+
+// ElapsedDays is the number of days of the range up to today.
+func (s *Stats) ElapsedDays() int {
+	return s.Range.ElapsedDays(s.Today)
+}
+
+// ActiveDaysPercent is the share of elapsed days with deep work.
+func (s *Stats) ActiveDaysPercent() float64 {
+	if s.ElapsedDays() == 0 {
+		return 0
+	}
+	return float64(s.ActiveDays()) / float64(s.ElapsedDays()) * 100
+}
+
+// BestDay returns the day with the most deep work.
+func (s *Stats) BestDay() (DayStats, bool) {
+	if s.Empty() {
+		return DayStats{}, false
+	}
+	best := s.Days[0]
+	for _, day := range s.Days {
+		if day.Duration > best.Duration {
+			best = day
+		}
+	}
+	return best, true
+}
+
+// LongestSessionDay returns the day of the longest session.
+func (s *Stats) LongestSessionDay() (DayStats, bool) {
+	if s.Empty() {
+		return DayStats{}, false
+	}
+	longest := s.Days[0]
+	for _, day := range s.Days {
+		if day.LongestSession > longest.LongestSession {
+			longest = day
+		}
+	}
+	return longest, true
+}
+
+// TopDays returns up to n days ordered by deep work, most first.
+func (s *Stats) TopDays(n int) []DayStats {
+	top := append([]DayStats(nil), s.Days...)
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].Duration > top[j].Duration
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// DayOf returns the totals of a day, which are zero if it had no deep work.
+func (s *Stats) DayOf(date time.Time) DayStats {
+	for _, day := range s.Days {
+		if dayKey(day.Date) == dayKey(date) {
+			return day
+		}
+	}
+	return DayStats{Date: date}
+}
+
+// SessionsOn returns the sessions worked on a day.
+func (s *Stats) SessionsOn(date time.Time) []notes.Session {
+	var sessions []notes.Session
+	for _, session := range s.Sessions {
+		if dayKey(session.Date) == dayKey(date) {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// LoggedSessions returns the sessions as they were logged, joining pieces
+// split at midnight back into one session dated the day it started. Entries
+// saved with the split midnight policy end a second before the boundary, so
+// a piece starting within a second of the previous one's end is joined too.
+// The part of a session that started before the range stays a continued
+// piece.
+func (s *Stats) LoggedSessions() []notes.Session {
+	var sessions []notes.Session
+	for _, session := range s.Sessions {
+		if session.Continued && len(sessions) > 0 {
+			last := &sessions[len(sessions)-1]
+			if gap := session.Start.Sub(last.End); gap >= 0 && gap <= time.Second {
+				last.End = session.End
+				last.Duration += session.Duration
+				continue
+			}
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
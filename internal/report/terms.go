@@ -0,0 +1,291 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// minTermSessions is how many sessions must mention a term for it to
+	// count as recurring.
+	minTermSessions = 2
+	// maxInterruptionTerms and maxThemes limit how many terms are shown.
+	maxInterruptionTerms = 8
+	maxThemes            = 5
+	// trendThreshold is how many more mentions one half of the range needs
+	// than the other for a term to be rising or falling.
+	trendThreshold = 2
+	// trendWidth is the width of the sparkline of a term's mentions.
+	trendWidth = 14
+)
+
+// DefaultStopwords are ignored when looking for recurring terms: common
+// English words, and words that say little about a distraction or theme.
+var DefaultStopwords = []string{
+	"a", "about", "after", "again", "all", "also", "am", "an", "and", "any", "are", "as", "at",
+	"be", "because", "been", "before", "being", "but", "by", "can", "could", "did", "do", "does",
+	"doing", "done", "during", "each", "even", "few", "for", "from", "get", "got", "had", "has",
+	"have", "having", "he", "her", "here", "him", "his", "how", "i", "if", "in", "into", "is", "it",
+	"its", "just", "less", "let", "lot", "made", "make", "me", "more", "most", "much", "my", "need",
+	"next", "no", "none", "not", "of", "off", "on", "once", "one", "only", "or", "other", "our",
+	"out", "over", "really", "same", "she", "should", "so", "some", "still", "such", "than", "that",
+	"the", "their", "them", "then", "there", "these", "they", "this", "those", "through", "time",
+	"to", "too", "up", "very", "was", "we", "were", "what", "when", "where", "which", "while",
+	"who", "why", "will", "with", "would", "you", "your",
+	"bit", "interrupted", "interruption", "interruptions", "minor", "session", "times", "went",
+}
+
+// Vocabulary controls how interruptions and reflections are split into
+// terms.
+type Vocabulary struct {
+	// Stopwords are ignored as well as DefaultStopwords.
+	Stopwords []string
+	// Synonyms maps words or phrases to the term they count as, such as
+	// "teams" and "chat" to "slack".
+	Synonyms map[string]string
+}
+
+// Terms splits text into lower case words, replaces synonyms and drops
+// stopwords and numbers, keeping the order of what remains.
+func (v Vocabulary) Terms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	stopwords := make(map[string]bool, len(DefaultStopwords)+len(v.Stopwords))
+	for _, list := range [][]string{DefaultStopwords, v.Stopwords} {
+		for _, word := range list {
+			stopwords[strings.ToLower(strings.TrimSpace(word))] = true
+		}
+	}
+
+	// Phrases are matched longest first, so "team chat" wins over "chat".
+	longest := 1
+	synonyms := make(map[string]string, len(v.Synonyms))
+	for from, to := range v.Synonyms {
+		from = strings.Join(strings.Fields(strings.ToLower(from)), " ")
+		synonyms[from] = strings.ToLower(strings.TrimSpace(to))
+		longest = max(longest, len(strings.Fields(from)))
+	}
+
+	var terms []string
+	for i := 0; i < len(words); {
+		term, consumed := words[i], 1
+		for n := min(longest, len(words)-i); n >= 1; n-- {
+			if to, ok := synonyms[strings.Join(words[i:i+n], " ")]; ok {
+				term, consumed = to, n
+				break
+			}
+		}
+		i += consumed
+
+		term = strings.Trim(term, "'")
+		if len([]rune(term)) < 2 || stopwords[term] || isNumber(term) {
+			continue
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+func isNumber(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// TermCount is how often a term recurs across sessions.
+type TermCount struct {
+	Term     string
+	Sessions int
+	// Daily holds the number of sessions mentioning the term on each day of
+	// the range up to today.
+	Daily []float64
+}
+
+// Trend compares mentions in the second half of the range with the first:
+// 1 when rising, -1 when falling and 0 when steady.
+func (t TermCount) Trend() int {
+	half := len(t.Daily) / 2
+	first, second := 0.0, 0.0
+	for i, count := range t.Daily {
+		if i < half {
+			first += count
+		} else if i >= len(t.Daily)-half {
+			second += count
+		}
+	}
+	switch {
+	case second-first >= trendThreshold:
+		return 1
+	case first-second >= trendThreshold:
+		return -1
+	}
+	return 0
+}
+
+func trendName(trend int) string {
+	switch trend {
+	case 1:
+		return "rising"
+	case -1:
+		return "falling"
+	}
+	return "steady"
+}
+
+// trendSparkline draws mentions per day scaled to the busiest stretch.
+func trendSparkline(daily []float64, cs Charset) string {
+	values := fitValues(daily, trendWidth)
+	high := 0.0
+	for _, value := range values {
+		high = max(high, value)
+	}
+	return padRight(Sparkline(values, 0, high, trendWidth, cs), trendWidth)
+}
+
+// TextInsights are the recurring distraction sources named in
+// interruptions and the recurring themes of reflections.
+type TextInsights struct {
+	Sessions            int
+	InterruptedSessions int
+	Interruptions       []TermCount
+
+	ReflectedSessions int
+	// Themes are words or pairs of words recurring in reflections.
+	Themes []TermCount
+}
+
+// TextInsights analyses the interruptions and reflections logged in the
+// range. It reports false if none were logged.
+func (s *Stats) TextInsights(v Vocabulary) (TextInsights, bool) {
+	dates := s.ElapsedDates()
+	index := make(map[string]int, len(dates))
+	for i, date := range dates {
+		index[dayKey(date)] = i
+	}
+
+	insights := TextInsights{}
+	interruptions := make(map[string]*TermCount)
+	themes := make(map[string]*TermCount)
+	count := func(counts map[string]*TermCount, terms []string, day int, ok bool) {
+		for _, term := range terms {
+			if counts[term] == nil {
+				counts[term] = &TermCount{Term: term, Daily: make([]float64, len(dates))}
+			}
+			counts[term].Sessions++
+			if ok {
+				counts[term].Daily[day]++
+			}
+		}
+	}
+
+	for _, session := range s.Sessions {
+		if session.Continued {
+			continue
+		}
+		insights.Sessions++
+		day, ok := index[dayKey(session.Date)]
+
+		if strings.TrimSpace(session.Interruptions) != "" {
+			insights.InterruptedSessions++
+			count(interruptions, unique(v.Terms(session.Interruptions)), day, ok)
+		}
+		if strings.TrimSpace(session.Reflection) != "" {
+			insights.ReflectedSessions++
+			count(themes, unique(withBigrams(v.Terms(session.Reflection))), day, ok)
+		}
+	}
+
+	if insights.InterruptedSessions == 0 && insights.ReflectedSessions == 0 {
+		return insights, false
+	}
+
+	insights.Interruptions = recurring(foldPlurals(interruptions), maxInterruptionTerms)
+	insights.Themes = recurring(dropSubsumed(foldPlurals(themes)), maxThemes)
+	return insights, true
+}
+
+// withBigrams adds each pair of neighbouring terms, since themes such as
+// "phone away" or "clear goal" say more than their words alone.
+func withBigrams(terms []string) []string {
+	all := append([]string(nil), terms...)
+	for i := 0; i+1 < len(terms); i++ {
+		if terms[i] != terms[i+1] {
+			all = append(all, terms[i]+" "+terms[i+1])
+		}
+	}
+	return all
+}
+
+func unique(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	var out []string
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			out = append(out, term)
+		}
+	}
+	return out
+}
+
+// foldPlurals counts "meetings" as "meeting" when both are used.
+func foldPlurals(counts map[string]*TermCount) map[string]*TermCount {
+	for term, plural := range counts {
+		singular, ok := counts[strings.TrimSuffix(term, "s")]
+		if !strings.HasSuffix(term, "s") || !ok || singular == plural {
+			continue
+		}
+		singular.Sessions += plural.Sessions
+		for i, n := range plural.Daily {
+			singular.Daily[i] += n
+		}
+		delete(counts, term)
+	}
+	return counts
+}
+
+// dropSubsumed removes words that only recur as part of a recurring pair.
+func dropSubsumed(counts map[string]*TermCount) map[string]*TermCount {
+	for term, pair := range counts {
+		words := strings.Fields(term)
+		if len(words) != 2 || pair.Sessions < minTermSessions {
+			continue
+		}
+		for _, word := range words {
+			if single, ok := counts[word]; ok && single.Sessions <= pair.Sessions {
+				delete(counts, word)
+			}
+		}
+	}
+	return counts
+}
+
+// recurring returns up to n terms mentioned in enough sessions, most
+// mentioned first.
+func recurring(counts map[string]*TermCount, n int) []TermCount {
+	var terms []TermCount
+	for _, count := range counts {
+		if count.Sessions >= minTermSessions {
+			terms = append(terms, *count)
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Sessions != terms[j].Sessions {
+			return terms[i].Sessions > terms[j].Sessions
+		}
+		return terms[i].Term < terms[j].Term
+	})
+	if len(terms) > n {
+		terms = terms[:n]
+	}
+	return terms
+}
@@ -0,0 +1,315 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+func renderText(w io.Writer, s *Stats, opts RenderOptions) error {
+	if s.Empty() {
+		_, err := fmt.Fprintf(w, "No sessions found for %s.\n", s.Range.Title())
+		return err
+	}
+
+	var b strings.Builder
+	if s.Range.Kind == RangeDay {
+		writeDayText(&b, s, opts)
+	} else {
+		writeRangeText(&b, s, opts)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeTextHeader(b *strings.Builder, title string, cs Charset) {
+	rule := strings.Repeat(cs.Rule, 59)
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, rule)
+	fmt.Fprintf(b, "  %s\n", title)
+	fmt.Fprintln(b, rule)
+	fmt.Fprintln(b)
+}
+
+func writeTotalsText(b *strings.Builder, s *Stats) {
+	fmt.Fprintf(b, "Total sessions: %d\n", s.Total.Sessions)
+	fmt.Fprintf(b, "Total deep work: %.1f hours (%d minutes)\n", s.Total.Duration.Hours(), int(s.Total.Duration.Minutes()))
+	fmt.Fprintf(b, "Average session: %.0f minutes\n", s.AvgSession().Minutes())
+
+	if s.Total.FocusQualityCount > 0 {
+		fmt.Fprintf(b, "Average rating: %.1f / 5\n", s.Total.AvgFocusQuality())
+	}
+}
+
+func writeRangeText(b *strings.Builder, s *Stats, opts RenderOptions) {
+	cs := opts.charset()
+	writeTextHeader(b, "Deep Work Report: "+s.Range.Title(), cs)
+	writeTotalsText(b, s)
+
+	bestDay, _ := s.BestDay()
+	fmt.Fprintf(b, "Best day: %s %s %.1f hours (%d sessions)\n",
+		bestDay.Date.Format("Jan 2"),
+		cs.Dash,
+		bestDay.Duration.Hours(),
+		bestDay.Sessions)
+
+	longestDay, _ := s.LongestSessionDay()
+	fmt.Fprintf(b, "Longest session: %d minutes (%s)\n",
+		int(longestDay.LongestSession.Minutes()),
+		longestDay.Date.Format("Jan 2"))
+
+	if days := s.ElapsedDays(); days > 0 {
+		fmt.Fprintf(b, "Days with deep work: %d / %d (%.0f%%)\n",
+			s.ActiveDays(),
+			days,
+			s.ActiveDaysPercent())
+	}
+
+	if s.Total.FocusQualityCount > 0 {
+		fmt.Fprintf(b, "Total rating points: %d\n", s.Total.FocusQualityTotal)
+	}
+
+	writeComparisonText(b, s, opts)
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, "Top performing days:")
+
+	for _, day := range s.TopDays(5) {
+		fmt.Fprintf(b, "%s: %.1fh (%s)\n",
+			day.Date.Format("Jan 2"),
+			day.Duration.Hours(),
+			cs.Stars(day.AvgFocusQuality()))
+	}
+
+	fmt.Fprintln(b)
+	if s.FastDays > 0 {
+		fmt.Fprintln(b, fastNote)
+		fmt.Fprintln(b)
+	}
+	writeMilestonesText(b, s, cs)
+	writeChartsText(b, s, opts)
+	writeTimeOfDayText(b, s, opts)
+	writeWeekdaysText(b, s, opts)
+	writeLengthText(b, s, opts)
+	writeTermsText(b, s, opts)
+}
+
+func writeDayText(b *strings.Builder, s *Stats, opts RenderOptions) {
+	cs := opts.charset()
+	writeTextHeader(b, "Deep Work Report: "+s.Range.Title(), cs)
+	writeTotalsText(b, s)
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, "Sessions:")
+	for _, session := range s.Sessions {
+		timeRange := "             "
+		if !session.Start.IsZero() && !session.End.IsZero() {
+			timeRange = fmt.Sprintf("%s - %s", session.Start.Format("15:04"), session.End.Format("15:04"))
+		}
+
+		rating := cs.Stars(float64(session.FocusQuality))
+		if session.Continued {
+			rating = "(continued)"
+		}
+
+		fmt.Fprintf(b, "  %s  %3d min  %s  %s\n",
+			timeRange,
+			int(session.Duration.Minutes()),
+			rating,
+			session.Milestone)
+	}
+
+	fmt.Fprintln(b)
+}
+
+// RatingStars draws a rating with Unicode stars, as Markdown output does.
+func RatingStars(rating float64) string {
+	return UnicodeCharset.Stars(rating)
+}
+
+// Stars draws a rating out of five, rounded to whole stars.
+func (cs Charset) Stars(rating float64) string {
+	filled := int(math.Round(rating))
+	if filled < 0 {
+		filled = 0
+	}
+	if filled > 5 {
+		filled = 5
+	}
+	return strings.Repeat(cs.Star, filled) + strings.Repeat(cs.NoStar, 5-filled)
+}
+
+func writeTimeOfDayText(b *strings.Builder, s *Stats, opts RenderOptions) {
+	t, ok := s.TimeOfDay()
+	if !ok {
+		return
+	}
+
+	var rows []BarRow
+	for _, hour := range t.ActiveHours() {
+		text := fmt.Sprintf("%4.1fh", hour.Duration.Hours())
+		if hour.FocusQualityCount > 0 {
+			text += fmt.Sprintf("  focus %.1f", hour.AvgFocusQuality())
+		}
+		rows = append(rows, BarRow{Label: formatHour(hour.Hour), Value: hour.Duration.Minutes(), Text: text})
+	}
+
+	fmt.Fprintln(b, "Deep work by hour (focus by start hour):")
+	writeBarChart(b, rows, opts.width(), opts.charset())
+	fmt.Fprintln(b)
+
+	fmt.Fprintf(b, "Most productive window: %s (%.0f%% of deep work", t.PeakWindow(), t.PeakShare())
+	if t.PeakRatedSessions > 0 {
+		fmt.Fprintf(b, ", average focus %.1f", t.PeakFocusQuality)
+	}
+	fmt.Fprintln(b, ")")
+	fmt.Fprintln(b)
+}
+
+func writeComparisonText(b *strings.Builder, s *Stats, opts RenderOptions) {
+	changes := s.Changes()
+	if changes == nil {
+		return
+	}
+	cs := opts.charset()
+
+	fmt.Fprintln(b)
+	fmt.Fprintf(b, "Compared with %s:\n", s.Previous.Range.Title())
+	for _, change := range changes {
+		indicator := cs.Same
+		switch change.Direction() {
+		case 1:
+			indicator = cs.Up
+		case -1:
+			indicator = cs.Down
+		}
+
+		percent := "new"
+		if value, ok := change.Percent(); ok {
+			percent = fmt.Sprintf("%+.0f%%", value)
+		} else if change.Current == 0 {
+			percent = "-"
+		}
+
+		fmt.Fprintf(b, "  %-16s %7s  %s %s (%s)\n",
+			change.Name,
+			change.Format(change.Current, false),
+			indicator,
+			change.Format(change.Delta(), true),
+			percent)
+	}
+}
+
+func writeWeekdaysText(b *strings.Builder, s *Stats, opts RenderOptions) {
+	weekdays, ok := s.Weekdays()
+	if !ok {
+		return
+	}
+
+	var rows []BarRow
+	for _, w := range weekdays {
+		text := fmt.Sprintf("%4.1fh  %3.1f sessions  active %d/%d  %s%.0fm",
+			w.AvgDuration().Hours(),
+			w.AvgSessions(),
+			w.ActiveDays,
+			w.Days,
+			opts.charset().PlusMinus,
+			w.StdDev.Minutes())
+		if w.FocusQualityCount > 0 {
+			text += fmt.Sprintf("  focus %.1f", w.AvgFocusQuality())
+		}
+		rows = append(rows, BarRow{Label: w.Weekday.String()[:3], Value: w.AvgDuration().Minutes(), Text: text})
+	}
+
+	fmt.Fprintln(b, "Average by weekday:")
+	writeBarChart(b, rows, opts.width(), opts.charset())
+	fmt.Fprintln(b)
+
+	strongest, weakest := StrongestWeekday(weekdays), WeakestWeekday(weekdays)
+	fmt.Fprintf(b, "Strongest day: %s (%.1fh on average, deep work on %.0f%% of them)\n",
+		strongest.Weekday, strongest.AvgDuration().Hours(), strongest.Consistency())
+	fmt.Fprintf(b, "Weakest day: %s (%.1fh on average, deep work on %.0f%% of them)\n",
+		weakest.Weekday, weakest.AvgDuration().Hours(), weakest.Consistency())
+	fmt.Fprintln(b)
+}
+
+func writeLengthText(b *strings.Builder, s *Stats, opts RenderOptions) {
+	l, ok := s.LengthInsights()
+	if !ok {
+		return
+	}
+
+	var rows []BarRow
+	for _, bucket := range l.Buckets {
+		text := fmt.Sprintf("%d sessions", bucket.Sessions)
+		if bucket.FocusQualityCount > 0 {
+			text = fmt.Sprintf("focus %.1f  (%s)", bucket.AvgFocusQuality(), text)
+		}
+		rows = append(rows, BarRow{Label: bucket.Label, Value: bucket.AvgFocusQuality(), Text: text})
+	}
+
+	fmt.Fprintln(b, "Focus by session length:")
+	writeScaledBarChart(b, rows, 5, opts.width(), opts.charset())
+	fmt.Fprintln(b)
+
+	fmt.Fprintf(b, "Length and focus show %s correlation", l.CorrelationStrength())
+	if l.HasCorrelation {
+		fmt.Fprintf(b, " (r = %.2f)", l.Correlation)
+	}
+	fmt.Fprintln(b, ".")
+	if l.SweetSpot >= 0 {
+		spot := l.Buckets[l.SweetSpot]
+		fmt.Fprintf(b, "Sweet spot: %s sessions (average focus %.1f).\n", spot.Label, spot.AvgFocusQuality())
+	}
+	if l.LongSessionsWorse {
+		fmt.Fprintf(b, "Sessions of %.0fm or more rate %.1f lower than shorter ones (%.1f vs %.1f). Consider splitting them up.\n",
+			LongSession.Minutes(),
+			l.ShortAvgFocusQuality-l.LongAvgFocusQuality,
+			l.LongAvgFocusQuality,
+			l.ShortAvgFocusQuality)
+	}
+	fmt.Fprintln(b)
+}
+
+func writeTermsText(b *strings.Builder, s *Stats, opts RenderOptions) {
+	t, ok := s.TextInsights(opts.Vocabulary)
+	if !ok {
+		return
+	}
+	cs := opts.charset()
+
+	if len(t.Interruptions) > 0 {
+		var rows []BarRow
+		for _, term := range t.Interruptions {
+			trend := cs.Same
+			switch term.Trend() {
+			case 1:
+				trend = cs.Up
+			case -1:
+				trend = cs.Down
+			}
+			rows = append(rows, BarRow{
+				Label: term.Term,
+				Value: float64(term.Sessions),
+				Text:  fmt.Sprintf("%2d sessions  %s %s", term.Sessions, trendSparkline(term.Daily, cs), trend),
+			})
+		}
+
+		fmt.Fprintf(b, "Recurring interruptions (%d of %d sessions interrupted):\n", t.InterruptedSessions, t.Sessions)
+		writeBarChart(b, rows, opts.width(), cs)
+		fmt.Fprintln(b)
+	}
+
+	if len(t.Themes) > 0 {
+		fmt.Fprintf(b, "Reflection themes (from %d reflections):\n", t.ReflectedSessions)
+		for _, theme := range t.Themes {
+			fmt.Fprintf(b, "  %-24s %d sessions\n", theme.Term, theme.Sessions)
+		}
+		fmt.Fprintln(b)
+	}
+}
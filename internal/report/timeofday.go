@@ -0,0 +1,144 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import "time"
+
+// PeakWindowHours is the length of the most productive window reported.
+const PeakWindowHours = 2
+
+type HourStats struct {
+	Hour     int
+	Duration time.Duration
+	// Sessions started in this hour, and their ratings.
+	Sessions          int
+	FocusQualityTotal int
+	FocusQualityCount int
+}
+
+func (h HourStats) AvgFocusQuality() float64 {
+	if h.FocusQualityCount == 0 {
+		return 0
+	}
+	return float64(h.FocusQualityTotal) / float64(h.FocusQualityCount)
+}
+
+// TimeOfDay spreads deep work over the hours of the day it was worked.
+type TimeOfDay struct {
+	Hours [24]HourStats
+	Total time.Duration
+
+	// The PeakWindowHours hours with the most deep work, which may wrap
+	// past midnight.
+	PeakStart         int
+	PeakDuration      time.Duration
+	PeakFocusQuality  float64
+	PeakRatedSessions int
+}
+
+// TimeOfDay analyses the sessions with logged start and end times. It
+// reports false if there are none.
+func (s *Stats) TimeOfDay() (TimeOfDay, bool) {
+	var t TimeOfDay
+	timed := false
+
+	for _, session := range s.Sessions {
+		if session.Start.IsZero() || session.End.IsZero() {
+			continue
+		}
+		timed = true
+		t.Total += session.Duration
+
+		if !session.Continued {
+			hour := &t.Hours[session.Start.Hour()]
+			hour.Sessions++
+			if session.FocusQuality > 0 {
+				hour.FocusQualityTotal += session.FocusQuality
+				hour.FocusQualityCount++
+			}
+		}
+
+		wallClock := session.End.Sub(session.Start)
+		if wallClock <= 0 {
+			t.Hours[session.Start.Hour()].Duration += session.Duration
+			continue
+		}
+
+		// Apportion the logged duration, which excludes pauses, by the
+		// share of wall-clock time spent in each hour.
+		for start := session.Start; start.Before(session.End); {
+			end := start.Truncate(time.Hour).Add(time.Hour)
+			if end.After(session.End) {
+				end = session.End
+			}
+			share := time.Duration(float64(session.Duration) * float64(end.Sub(start)) / float64(wallClock))
+			t.Hours[start.Hour()].Duration += share
+			start = end
+		}
+	}
+	if !timed {
+		return TimeOfDay{}, false
+	}
+
+	for hour := range t.Hours {
+		t.Hours[hour].Hour = hour
+	}
+
+	for start := 0; start < 24; start++ {
+		var duration time.Duration
+		for offset := 0; offset < PeakWindowHours; offset++ {
+			duration += t.Hours[(start+offset)%24].Duration
+		}
+		if duration > t.PeakDuration {
+			t.PeakStart = start
+			t.PeakDuration = duration
+		}
+	}
+
+	focusTotal := 0
+	for offset := 0; offset < PeakWindowHours; offset++ {
+		hour := t.Hours[(t.PeakStart+offset)%24]
+		focusTotal += hour.FocusQualityTotal
+		t.PeakRatedSessions += hour.FocusQualityCount
+	}
+	if t.PeakRatedSessions > 0 {
+		t.PeakFocusQuality = float64(focusTotal) / float64(t.PeakRatedSessions)
+	}
+
+	return t, true
+}
+
+// PeakShare is the share of deep work done in the peak window, in percent.
+func (t TimeOfDay) PeakShare() float64 {
+	if t.Total == 0 {
+		return 0
+	}
+	return float64(t.PeakDuration) / float64(t.Total) * 100
+}
+
+// ActiveHours returns the hours from the first to the last with deep work.
+func (t TimeOfDay) ActiveHours() []HourStats {
+	first, last := -1, -1
+	for hour, stats := range t.Hours {
+		if stats.Duration > 0 || stats.Sessions > 0 {
+			if first < 0 {
+				first = hour
+			}
+			last = hour
+		}
+	}
+	if first < 0 {
+		return nil
+	}
+	return t.Hours[first : last+1]
+}
+
+func formatHour(hour int) string {
+	return time.Date(0, 1, 1, hour%24, 0, 0, 0, time.UTC).Format("15:04")
+}
+
+// PeakWindow formats the peak window, e.g. "09:00-11:00".
+func (t TimeOfDay) PeakWindow() string {
+	return formatHour(t.PeakStart) + "-" + formatHour(t.PeakStart+PeakWindowHours)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package report
+
+import (
+	"math"
+	"time"
+)
+
+// minWeekdaySamples is how many of each weekday a range needs before
+// weekday patterns are reported.
+const minWeekdaySamples = 2
+
+type WeekdayStats struct {
+	Weekday time.Weekday
+	// Days is how many of this weekday the range has had so far, and
+	// ActiveDays how many of them had deep work.
+	Days              int
+	ActiveDays        int
+	Duration          time.Duration
+	Sessions          int
+	FocusQualityTotal int
+	FocusQualityCount int
+	// StdDev is the standard deviation of the day's deep work.
+	StdDev time.Duration
+}
+
+func (w WeekdayStats) AvgDuration() time.Duration {
+	if w.Days == 0 {
+		return 0
+	}
+	return w.Duration / time.Duration(w.Days)
+}
+
+func (w WeekdayStats) AvgSessions() float64 {
+	if w.Days == 0 {
+		return 0
+	}
+	return float64(w.Sessions) / float64(w.Days)
+}
+
+func (w WeekdayStats) AvgFocusQuality() float64 {
+	if w.FocusQualityCount == 0 {
+		return 0
+	}
+	return float64(w.FocusQualityTotal) / float64(w.FocusQualityCount)
+}
+
+// Consistency is the share of these weekdays with deep work, in percent.
+func (w WeekdayStats) Consistency() float64 {
+	if w.Days == 0 {
+		return 0
+	}
+	return float64(w.ActiveDays) / float64(w.Days) * 100
+}
+
+// Weekdays averages each weekday over the days of the range so far,
+// Monday first, counting days without deep work as zero. It reports false
+// when the range is too short for the averages to mean much.
+func (s *Stats) Weekdays() ([]WeekdayStats, bool) {
+	byDay := make(map[string]DayStats)
+	for _, day := range s.Days {
+		byDay[dayKey(day.Date)] = day
+	}
+
+	weekdays := make([]WeekdayStats, 7)
+	daily := make([][]time.Duration, 7)
+	for i := range weekdays {
+		weekdays[i].Weekday = time.Weekday((i + 1) % 7)
+	}
+
+	for _, date := range s.ElapsedDates() {
+		i := (int(date.Weekday()) + 6) % 7
+		day := byDay[dayKey(date)]
+
+		w := &weekdays[i]
+		w.Days++
+		if day.Duration > 0 || day.Sessions > 0 {
+			w.ActiveDays++
+		}
+		w.Duration += day.Duration
+		w.Sessions += day.Sessions
+		w.FocusQualityTotal += day.FocusQualityTotal
+		w.FocusQualityCount += day.FocusQualityCount
+		daily[i] = append(daily[i], day.Duration)
+	}
+
+	for i := range weekdays {
+		if weekdays[i].Days < minWeekdaySamples {
+			return nil, false
+		}
+		weekdays[i].StdDev = stdDev(daily[i])
+	}
+
+	return weekdays, true
+}
+
+// StrongestWeekday and WeakestWeekday pick the weekdays with the most and
+// least deep work on average.
+func StrongestWeekday(weekdays []WeekdayStats) WeekdayStats {
+	best := weekdays[0]
+	for _, w := range weekdays {
+		if w.AvgDuration() > best.AvgDuration() {
+			best = w
+		}
+	}
+	return best
+}
+
+func WeakestWeekday(weekdays []WeekdayStats) WeekdayStats {
+	worst := weekdays[0]
+	for _, w := range weekdays {
+		if w.AvgDuration() < worst.AvgDuration() {
+			worst = w
+		}
+	}
+	return worst
+}
+
+func stdDev(values []time.Duration) time.Duration {
+	if len(values) == 0 {
+		return 0
+	}
+	var mean float64
+	for _, value := range values {
+		mean += float64(value)
+	}
+	mean /= float64(len(values))
+
+	var variance float64
+	for _, value := range values {
+		variance += (float64(value) - mean) * (float64(value) - mean)
+	}
+	return time.Duration(math.Sqrt(variance / float64(len(values))))
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package dashboard
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"altum/internal/notes"
+	"altum/internal/report"
+)
+
+type statsLoadedMsg struct {
+	reportRange report.Range
+	stats       *report.Stats
+	err         error
+}
+
+// loadStats reads the range and, for comparison, the period before it. Both
+// are parsed the same way, so sessions crossing midnight count alike.
+func loadStats(notesConfig notes.Config, reportRange report.Range) tea.Cmd {
+	return func() tea.Msg {
+		stats, _, err := report.Load(notesConfig, reportRange, false)
+		if err != nil {
+			return statsLoadedMsg{reportRange: reportRange, err: err}
+		}
+
+		previousRange := report.PreviousPeriod(reportRange, stats.Today)
+		if previous, _, err := report.Load(notesConfig, previousRange, false); err == nil {
+			stats.Previous = previous
+		}
+
+		return statsLoadedMsg{reportRange: reportRange, stats: stats}
+	}
+}
+
+func (m model) handleStatsLoaded(msg statsLoadedMsg) model {
+	// Ignore results for a range the user has already moved away from.
+	if msg.reportRange != m.reportRange {
+		return m
+	}
+
+	m.loading = false
+	m.stats = msg.stats
+	m.err = msg.err
+	if m.stats != nil {
+		m.dayCursor = max(len(m.stats.ElapsedDates())-1, 0)
+	}
+	m.sessionCursor = 0
+	m.trendsOffset = 0
+	m.openDay = nil
+	return m
+}
@@ -0,0 +1,19 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package dashboard
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"altum/internal/notes"
+	"altum/internal/report"
+)
+
+func Run(notesConfig notes.Config, reportRange report.Range, charset report.Charset) error {
+	m := InitialModel(notesConfig, reportRange, charset)
+	p := tea.NewProgram(m, tea.WithAltScreen())
+
+	_, err := p.Run()
+	return err
+}
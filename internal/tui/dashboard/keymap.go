@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package dashboard
+
+import (
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type KeyMap struct {
+	Quit       key.Binding
+	NextTab    key.Binding
+	PrevTab    key.Binding
+	PrevPeriod key.Binding
+	NextPeriod key.Binding
+	Today      key.Binding
+	Week       key.Binding
+	Month      key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Open       key.Binding
+	Back       key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	Quit: key.NewBinding(
+		key.WithKeys("ctrl+c", "q"),
+		key.WithHelp("q", "quit"),
+	),
+	NextTab: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "next tab"),
+	),
+	PrevTab: key.NewBinding(
+		key.WithKeys("shift+tab"),
+		key.WithHelp("shift+tab", "previous tab"),
+	),
+	PrevPeriod: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "previous period"),
+	),
+	NextPeriod: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "next period"),
+	),
+	Today: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "current period"),
+	),
+	Week: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "week"),
+	),
+	Month: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "month"),
+	),
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"),
+	),
+	Open: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "open day"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc", "backspace"),
+		key.WithHelp("esc", "back"),
+	),
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Quit}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.NextTab, k.PrevTab},
+		{k.PrevPeriod, k.NextPeriod, k.Today},
+		{k.Week, k.Month},
+		{k.Up, k.Down, k.Open, k.Back},
+		{k.Quit},
+	}
+}
+
+func (k KeyMap) OverviewHelp() []key.Binding {
+	return []key.Binding{k.NextTab, k.PrevPeriod, k.NextPeriod, k.Week, k.Month, k.Quit}
+}
+
+func (k KeyMap) ListHelp() []key.Binding {
+	return []key.Binding{k.NextTab, k.Up, k.Down, k.PrevPeriod, k.NextPeriod, k.Quit}
+}
+
+func (k KeyMap) DaysHelp() []key.Binding {
+	return []key.Binding{k.NextTab, k.Up, k.Down, k.Open, k.PrevPeriod, k.NextPeriod, k.Quit}
+}
+
+func (k KeyMap) DayHelp() []key.Binding {
+	return []key.Binding{k.Back, k.Up, k.Down, k.Quit}
+}
+
+type stateKeyMap struct {
+	bindings []key.Binding
+}
+
+func (k stateKeyMap) ShortHelp() []key.Binding {
+	return k.bindings
+}
+
+func (k stateKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.bindings}
+}
+
+func (k KeyMap) OverviewKeyMap() help.KeyMap {
+	return stateKeyMap{bindings: k.OverviewHelp()}
+}
+
+func (k KeyMap) ListKeyMap() help.KeyMap {
+	return stateKeyMap{bindings: k.ListHelp()}
+}
+
+func (k KeyMap) DaysKeyMap() help.KeyMap {
+	return stateKeyMap{bindings: k.DaysHelp()}
+}
+
+func (k KeyMap) DayKeyMap() help.KeyMap {
+	return stateKeyMap{bindings: k.DayHelp()}
+}
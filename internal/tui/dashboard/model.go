@@ -0,0 +1,233 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package dashboard
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"altum/internal/notes"
+	"altum/internal/report"
+)
+
+type tab int
+
+const (
+	tabOverview tab = iota
+	tabDays
+	tabSessions
+	tabTrends
+)
+
+var tabNames = []string{"Overview", "Days", "Sessions", "Trends"}
+
+type model struct {
+	notesConfig notes.Config
+	reportRange report.Range
+	stats       *report.Stats
+	loading     bool
+	err         error
+
+	tab           tab
+	dayCursor     int
+	sessionCursor int
+	trendsOffset  int
+	// openDay is the day drilled into from the Days tab, if any.
+	openDay *time.Time
+
+	width   int
+	height  int
+	charset report.Charset
+	spinner spinner.Model
+	help    help.Model
+	keyMap  KeyMap
+}
+
+// InitialModel opens the dashboard on a range. Charts are drawn with
+// charset, so the dashboard degrades like the text report does.
+func InitialModel(notesConfig notes.Config, reportRange report.Range, charset report.Charset) model {
+	return model{
+		notesConfig: notesConfig,
+		reportRange: reportRange,
+		loading:     true,
+		width:       report.DefaultWidth,
+		height:      24,
+		charset:     charset,
+		spinner:     spinner.New(),
+		help:        help.New(),
+		keyMap:      DefaultKeyMap,
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, loadStats(m.notesConfig, m.reportRange))
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case statsLoadedMsg:
+		return m.handleStatsLoaded(msg), nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		return m, nil
+
+	case spinner.TickMsg:
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keyMap.Quit):
+		return m, tea.Quit
+
+	case m.openDay != nil && key.Matches(msg, m.keyMap.Back):
+		m.openDay = nil
+		m.sessionCursor = 0
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.NextTab):
+		m.tab = (m.tab + 1) % tab(len(tabNames))
+		m.openDay = nil
+		m.sessionCursor = 0
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.PrevTab):
+		m.tab = (m.tab + tab(len(tabNames)) - 1) % tab(len(tabNames))
+		m.openDay = nil
+		m.sessionCursor = 0
+		return m, nil
+
+	case msg.String() >= "1" && msg.String() <= "4":
+		m.tab = tab(msg.String()[0] - '1')
+		m.openDay = nil
+		m.sessionCursor = 0
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.PrevPeriod):
+		return m.setRange(m.reportRange.Previous())
+
+	case key.Matches(msg, m.keyMap.NextPeriod):
+		return m.setRange(m.reportRange.Next())
+
+	case key.Matches(msg, m.keyMap.Today):
+		return m.setRange(m.rangeContaining(m.notesConfig.Today()))
+
+	case key.Matches(msg, m.keyMap.Week):
+		return m.setRange(report.Week(m.focusDate()))
+
+	case key.Matches(msg, m.keyMap.Month):
+		return m.setRange(report.Month(m.focusDate()))
+
+	case key.Matches(msg, m.keyMap.Up):
+		m.moveCursor(-1)
+		return m, nil
+
+	case key.Matches(msg, m.keyMap.Down):
+		m.moveCursor(1)
+		return m, nil
+
+	case m.tab == tabDays && m.openDay == nil && key.Matches(msg, m.keyMap.Open):
+		if m.stats != nil {
+			dates := m.stats.ElapsedDates()
+			if m.dayCursor < len(dates) {
+				day := dates[m.dayCursor]
+				m.openDay = &day
+				m.sessionCursor = 0
+			}
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m model) setRange(reportRange report.Range) (tea.Model, tea.Cmd) {
+	m.reportRange = reportRange
+	m.loading = true
+	m.openDay = nil
+	return m, tea.Batch(m.spinner.Tick, loadStats(m.notesConfig, reportRange))
+}
+
+// rangeContaining returns a range of the current kind containing date.
+func (m model) rangeContaining(date time.Time) report.Range {
+	switch m.reportRange.Kind {
+	case report.RangeDay:
+		return report.Day(date)
+	case report.RangeMonth:
+		return report.Month(date)
+	case report.RangeQuarter:
+		return report.Quarter(date)
+	case report.RangeYear:
+		return report.Year(date)
+	case report.RangeWeek:
+		return report.Week(date)
+	}
+	return report.LastDays(date, m.reportRange.Days())
+}
+
+// focusDate is the day switching between week and month views keeps in
+// view: the selected day, or the last day of the range so far.
+func (m model) focusDate() time.Time {
+	if m.stats != nil {
+		dates := m.stats.ElapsedDates()
+		if m.tab == tabDays && m.dayCursor < len(dates) {
+			return dates[m.dayCursor]
+		}
+		if len(dates) > 0 {
+			return dates[len(dates)-1]
+		}
+	}
+	return m.reportRange.Start
+}
+
+func (m *model) moveCursor(delta int) {
+	if m.stats == nil {
+		return
+	}
+	if m.tab == tabTrends {
+		m.trendsOffset = clamp(m.trendsOffset+delta, 0, len(m.trendsLines())-m.bodyHeight())
+		return
+	}
+	if m.tab == tabDays && m.openDay == nil {
+		m.dayCursor = clamp(m.dayCursor+delta, 0, len(m.stats.ElapsedDates())-1)
+		return
+	}
+	m.sessionCursor = clamp(m.sessionCursor+delta, 0, len(m.visibleSessions())-1)
+}
+
+// visibleSessions are the sessions listed by the current view.
+func (m model) visibleSessions() []notes.Session {
+	if m.stats == nil {
+		return nil
+	}
+	if m.openDay != nil {
+		return m.stats.SessionsOn(*m.openDay)
+	}
+	return m.stats.Sessions
+}
+
+func clamp(value, low, high int) int {
+	if high < low {
+		return low
+	}
+	return min(max(value, low), high)
+}
@@ -0,0 +1,22 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package dashboard
+
+import "github.com/charmbracelet/lipgloss"
+
+var (
+	TitleStyle       = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("7")).Padding(1, 2, 0)
+	RangeStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("8")).PaddingLeft(2)
+	TabStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Padding(0, 2)
+	ActiveTabStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("7")).Bold(true).Underline(true).Padding(0, 2)
+	BodyStyle        = lipgloss.NewStyle().Padding(1, 2)
+	HeadingStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("7")).Bold(true)
+	LabelStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	ValueStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("7")).Bold(true)
+	BarStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("7"))
+	DimStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	SelectedRowStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("7")).Bold(true)
+	ErrorStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Bold(true)
+	HelpStyle        = lipgloss.NewStyle().PaddingLeft(2)
+)
@@ -0,0 +1,347 @@
+/*
+Copyright © 2025 Eden Phillips
+*/
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/lipgloss"
+
+	"altum/internal/notes"
+	"altum/internal/report"
+)
+
+// dayLayout pads the day of the month so lists of days line up.
+const dayLayout = "Mon Jan _2"
+
+// chromeHeight is the number of lines around the body: title, range, tabs,
+// padding and help.
+const chromeHeight = 9
+
+func (m model) View() string {
+	var s string
+
+	s += TitleStyle.Render("Deep Work Dashboard")
+	s += "\n"
+	s += RangeStyle.Render(m.reportRange.Title())
+	s += "\n\n"
+	s += m.tabsView()
+	s += "\n"
+
+	var body string
+	var keyMap help.KeyMap
+	switch {
+	case m.loading:
+		body = m.spinner.View() + " Reading your daily notes..."
+		keyMap = m.keyMap.OverviewKeyMap()
+	case m.err != nil:
+		body = ErrorStyle.Render(fmt.Sprintf("Error reading sessions: %v", m.err))
+		keyMap = m.keyMap.OverviewKeyMap()
+	case m.openDay != nil:
+		body = m.dayView()
+		keyMap = m.keyMap.DayKeyMap()
+	case m.tab == tabDays:
+		body = m.daysView()
+		keyMap = m.keyMap.DaysKeyMap()
+	case m.tab == tabSessions:
+		body = m.sessionsView()
+		keyMap = m.keyMap.ListKeyMap()
+	case m.tab == tabTrends:
+		lines := m.trendsLines()
+		start := clamp(m.trendsOffset, 0, len(lines)-m.bodyHeight())
+		body = strings.Join(lines[start:], "\n")
+		keyMap = m.keyMap.ListKeyMap()
+	default:
+		body = m.overviewView()
+		keyMap = m.keyMap.OverviewKeyMap()
+	}
+
+	s += BodyStyle.Render(truncateLines(body, m.bodyHeight()))
+	s += "\n"
+	s += HelpStyle.Render(m.help.View(keyMap))
+
+	return s
+}
+
+func (m model) tabsView() string {
+	var tabs []string
+	for i, name := range tabNames {
+		label := fmt.Sprintf("%d %s", i+1, name)
+		if tab(i) == m.tab {
+			tabs = append(tabs, ActiveTabStyle.Render(label))
+		} else {
+			tabs = append(tabs, TabStyle.Render(label))
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+func (m model) bodyHeight() int {
+	return max(m.height-chromeHeight, 5)
+}
+
+func (m model) barWidth(reserved int) int {
+	return min(max(m.width-reserved-4, 10), 50)
+}
+
+func (m model) bar(value, largest float64, width int) string {
+	bar := report.Bar(value, largest, width, m.charset)
+	return BarStyle.Render(bar) + strings.Repeat(" ", width-utf8.RuneCountInString(bar))
+}
+
+func (m model) overviewView() string {
+	s := m.stats
+	if s.Empty() {
+		return DimStyle.Render("No sessions in this period.")
+	}
+
+	changes := map[string]report.Change{}
+	for _, change := range s.Changes() {
+		changes[change.Name] = change
+	}
+
+	var lines []string
+	line := func(name, value string) {
+		text := LabelStyle.Render(fmt.Sprintf("%-18s", name)) + ValueStyle.Render(fmt.Sprintf("%-12s", value))
+		if change, ok := changes[name]; ok {
+			text += DimStyle.Render(m.changeText(change))
+		}
+		lines = append(lines, text)
+	}
+
+	line("Deep work", fmt.Sprintf("%.1fh", s.Total.Duration.Hours()))
+	line("Sessions", fmt.Sprint(s.Total.Sessions))
+	line("Average session", fmt.Sprintf("%.0fm", s.AvgSession().Minutes()))
+	if s.Total.FocusQualityCount > 0 {
+		line("Average focus", fmt.Sprintf("%.1f", s.Total.AvgFocusQuality()))
+	}
+	line("Active days", fmt.Sprintf("%d / %d", s.ActiveDays(), s.ElapsedDays()))
+	if best, ok := s.BestDay(); ok {
+		line("Best day", fmt.Sprintf("%s %.1fh", best.Date.Format("Jan 2"), best.Duration.Hours()))
+	}
+	if longest, ok := s.LongestSessionDay(); ok {
+		line("Longest session", fmt.Sprintf("%.0fm %s", longest.LongestSession.Minutes(), longest.Date.Format("Jan 2")))
+	}
+	if s.Previous != nil {
+		lines = append(lines, "", DimStyle.Render("Changes are against "+s.Previous.Range.Title()))
+	}
+
+	lines = append(lines, "", HeadingStyle.Render("Top days"))
+	top := s.TopDays(5)
+	width := m.barWidth(20)
+	for _, day := range top {
+		lines = append(lines, fmt.Sprintf("%s  %s %.1fh  %s",
+			LabelStyle.Render(day.Date.Format("Mon Jan 2")),
+			m.bar(day.Duration.Hours(), top[0].Duration.Hours(), width),
+			day.Duration.Hours(),
+			m.charset.Stars(day.AvgFocusQuality())))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m model) changeText(change report.Change) string {
+	indicator := m.charset.Same
+	switch change.Direction() {
+	case 1:
+		indicator = m.charset.Up
+	case -1:
+		indicator = m.charset.Down
+	}
+	text := indicator + " " + change.Format(change.Delta(), true)
+	if percent, ok := change.Percent(); ok {
+		text += fmt.Sprintf(" (%+.0f%%)", percent)
+	}
+	return text
+}
+
+func (m model) daysView() string {
+	dates := m.stats.ElapsedDates()
+	if len(dates) == 0 {
+		return DimStyle.Render("This period hasn't started yet.")
+	}
+
+	largest := 0.0
+	for _, day := range m.stats.Days {
+		largest = max(largest, day.Duration.Hours())
+	}
+
+	width := m.barWidth(40)
+	var lines []string
+	for i, date := range dates {
+		day := m.stats.DayOf(date)
+		text := fmt.Sprintf("%s  %s %4.1fh  %s", date.Format(dayLayout), m.bar(day.Duration.Hours(), largest, width), day.Duration.Hours(), plural(day.Sessions, "session"))
+		if day.FocusQualityCount > 0 {
+			text += "  " + m.charset.Stars(day.AvgFocusQuality())
+		}
+		lines = append(lines, cursorLine(text, i == m.dayCursor))
+	}
+
+	return scrollLines(lines, m.dayCursor, m.bodyHeight())
+}
+
+func (m model) dayView() string {
+	day := m.stats.DayOf(*m.openDay)
+
+	header := []string{
+		HeadingStyle.Render(m.openDay.Format("Monday, January 2, 2006")),
+		fmt.Sprintf("%s %.1fh   %s %d   %s %.1f",
+			LabelStyle.Render("Deep work"), day.Duration.Hours(),
+			LabelStyle.Render("Sessions"), day.Sessions,
+			LabelStyle.Render("Focus"), day.AvgFocusQuality()),
+		"",
+	}
+
+	sessions := m.visibleSessions()
+	if len(sessions) == 0 {
+		return strings.Join(append(header, DimStyle.Render("No sessions on this day.")), "\n")
+	}
+
+	lines := m.sessionLines(sessions, false)
+	body := scrollLines(lines, m.sessionCursor, m.bodyHeight()-len(header)-3)
+	return strings.Join(header, "\n") + "\n" + body + "\n\n" + m.sessionDetail(sessions[m.sessionCursor])
+}
+
+func (m model) sessionsView() string {
+	sessions := m.visibleSessions()
+	if len(sessions) == 0 {
+		return DimStyle.Render("No sessions in this period.")
+	}
+
+	lines := m.sessionLines(sessions, true)
+	return scrollLines(lines, m.sessionCursor, m.bodyHeight()-3) + "\n\n" + m.sessionDetail(sessions[m.sessionCursor])
+}
+
+func (m model) sessionLines(sessions []notes.Session, withDate bool) []string {
+	var lines []string
+	for i, session := range sessions {
+		text := ""
+		if withDate {
+			text += session.Date.Format(dayLayout) + "  "
+		}
+		if !session.Start.IsZero() && !session.End.IsZero() {
+			text += session.Start.Format("15:04") + "-" + session.End.Format("15:04") + "  "
+		}
+		text += fmt.Sprintf("%4.0fm  ", session.Duration.Minutes())
+		if session.Continued {
+			text += "(continued)"
+		} else {
+			text += m.charset.Stars(float64(session.FocusQuality))
+		}
+		lines = append(lines, cursorLine(text, i == m.sessionCursor))
+	}
+	return lines
+}
+
+func (m model) sessionDetail(session notes.Session) string {
+	milestone := session.Milestone
+	if milestone == "" {
+		milestone = "(no milestone)"
+	}
+	return LabelStyle.Render("Milestone ") + lipgloss.NewStyle().Width(max(m.width-14, 20)).Render(milestone)
+}
+
+// trendsLines are the lines of the Trends tab, which scrolls as a whole.
+func (m model) trendsLines() []string {
+	s := m.stats
+	if s.Empty() {
+		return []string{DimStyle.Render("No sessions in this period.")}
+	}
+
+	var lines []string
+
+	rows := s.DeepWorkRows()
+	lines = append(lines, HeadingStyle.Render("Deep work"))
+	lines = append(lines, m.barRows(rows)...)
+
+	if s.Total.FocusQualityCount > 0 {
+		lines = append(lines, "", HeadingStyle.Render("Focus quality"))
+		lines = append(lines, BarStyle.Render(report.Sparkline(s.FocusSeries(), 1, 5, m.width-6, m.charset)))
+	}
+
+	if t, ok := s.TimeOfDay(); ok {
+		lines = append(lines, "", HeadingStyle.Render("Time of day"))
+		line := fmt.Sprintf("Most productive window %s, %.0f%% of deep work", t.PeakWindow(), t.PeakShare())
+		if t.PeakRatedSessions > 0 {
+			line += fmt.Sprintf(", focus %.1f", t.PeakFocusQuality)
+		}
+		lines = append(lines, line)
+	}
+
+	if weekdays, ok := s.Weekdays(); ok {
+		var weekdayRows []report.BarRow
+		for _, w := range weekdays {
+			weekdayRows = append(weekdayRows, report.BarRow{
+				Label: w.Weekday.String()[:3],
+				Value: w.AvgDuration().Hours(),
+				Text:  fmt.Sprintf("%.1fh  active %d/%d", w.AvgDuration().Hours(), w.ActiveDays, w.Days),
+			})
+		}
+		lines = append(lines, "", HeadingStyle.Render("Average by weekday"))
+		lines = append(lines, m.barRows(weekdayRows)...)
+	}
+
+	if l, ok := s.LengthInsights(); ok && l.SweetSpot >= 0 {
+		spot := l.Buckets[l.SweetSpot]
+		lines = append(lines, "", HeadingStyle.Render("Session length"))
+		lines = append(lines, fmt.Sprintf("Sweet spot %s (focus %.1f), %s correlation between length and focus",
+			spot.Label, spot.AvgFocusQuality(), l.CorrelationStrength()))
+	}
+
+	return lines
+}
+
+func (m model) barRows(rows []report.BarRow) []string {
+	labelWidth := 0
+	largest := 0.0
+	for _, row := range rows {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(row.Label))
+		largest = max(largest, row.Value)
+	}
+
+	width := m.barWidth(labelWidth + 24)
+	var lines []string
+	for _, row := range rows {
+		lines = append(lines, fmt.Sprintf("%s %s %s",
+			LabelStyle.Render(fmt.Sprintf("%-*s", labelWidth, row.Label)),
+			m.bar(row.Value, largest, width),
+			row.Text))
+	}
+	return lines
+}
+
+func cursorLine(text string, selected bool) string {
+	if selected {
+		return SelectedRowStyle.Render("▶ " + text)
+	}
+	return DimStyle.Render("  " + text)
+}
+
+// scrollLines shows the window of lines around the cursor that fits height.
+func scrollLines(lines []string, cursor, height int) string {
+	height = max(height, 1)
+	if len(lines) <= height {
+		return strings.Join(lines, "\n")
+	}
+	start := clamp(cursor-height/2, 0, len(lines)-height)
+	return strings.Join(lines[start:start+height], "\n")
+}
+
+func truncateLines(text string, height int) string {
+	lines := strings.Split(text, "\n")
+	if len(lines) <= height {
+		return text
+	}
+	return strings.Join(lines[:height], "\n")
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -13,6 +13,7 @@ type MenuItem int
 
 const (
 	MenuStart MenuItem = iota
+	MenuReport
 	MenuConfig
 	MenuExit
 )
@@ -49,12 +50,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor > 0 {
 				m.cursor--
 			} else {
-				m.cursor = 2
+				m.cursor = 3
 			}
 			m.selected = MenuItem(m.cursor)
 
 		case msg.String() == "down" || msg.String() == "j":
-			if m.cursor < 2 {
+			if m.cursor < 3 {
 				m.cursor++
 			} else {
 				m.cursor = 0
@@ -93,6 +94,7 @@ func (m model) View() string {
 
 	items := []string{
 		"Start Deep Work Session",
+		"View Report",
 		"️Configure Settings",
 		"Exit",
 	}
@@ -4,101 +4,118 @@ Copyright © 2025 Eden Phillips
 package session
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
-	"regexp"
-	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"altum/internal/coach"
+	"altum/internal/notes"
 )
 
 type saveSuccessMsg struct {
-	noteFilePath string
-	sessionCount int
+	logged []notes.LoggedEntry
 }
 
+// saveErrorMsg carries the parts of a split session saved before the error.
 type saveErrorMsg struct {
+	logged []notes.LoggedEntry
+	err    error
+}
+
+type tipMsg struct {
+	tip string
 	err error
 }
 
-func (m *model) saveSession() tea.Cmd {
+func (m model) fetchTip() tea.Cmd {
+	sessionCoach, notesConfig := *m.coach, m.notesConfig
 	return func() tea.Msg {
-		today := time.Now().Format(m.dateFormat)
-		noteFileName := fmt.Sprintf("%s.md", today)
-		noteFilePath := filepath.Join(m.dailyNotesPath, noteFileName)
-
-		file, err := os.OpenFile(noteFilePath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+		sessions, err := coach.LoadRecentSessions(notesConfig)
 		if err != nil {
-			return saveErrorMsg{err: err}
-		}
-		defer file.Close()
-
-		titleRe := regexp.MustCompile(`^## Altum Work Sessions$`)
-		sessionTitleRe := regexp.MustCompile(`^#### Session \d+`)
-
-		isTitleLineFound := false
-		sessionCount := 1
-
-		scanner := bufio.NewScanner(file)
-		for scanner.Scan() {
-			line := strings.TrimSpace(scanner.Text())
-			if !isTitleLineFound && titleRe.MatchString(line) {
-				isTitleLineFound = true
-				continue
-			}
-			if isTitleLineFound && sessionTitleRe.MatchString(line) {
-				sessionCount++
-				continue
-			}
+			return tipMsg{err: err}
 		}
 
-		if err := scanner.Err(); err != nil {
-			return saveErrorMsg{err: err}
-		}
+		tip, err := sessionCoach.Tip(context.Background(), sessions, time.Now())
+		return tipMsg{tip: tip, err: err}
+	}
+}
 
-		entry := ""
-		if !isTitleLineFound {
-			entry += "\n## Altum Work Sessions\n"
-		}
-		sessionStartTime := m.startTime.Format("15:04:05")
-		sessionEndTime := time.Now().Format("15:04:05")
-		entry += fmt.Sprintf("\n#### Session %d\n", sessionCount)
-		entry += fmt.Sprintf("- Time: %s - %s\n", sessionStartTime, sessionEndTime)
-		minutes := int(m.duration.Minutes())
-		seconds := int(m.duration.Seconds()) % 60
-		entry += fmt.Sprintf("- Duration: %d minutes %d seconds\n", minutes, seconds)
-		entry += fmt.Sprintf("- Milestone: %s\n", m.milestone)
-		entry += fmt.Sprintf("- Focus Quality: %s/5\n", m.focusQuality)
-		if m.interruptions != "" {
-			entry += fmt.Sprintf("- Interruptions: %s\n", m.interruptions)
-		}
-		if m.reflection != "" {
-			entry += fmt.Sprintf("- Reflection: %s\n", m.reflection)
-		}
+func (m model) handleTip(msg tipMsg) model {
+	m.tip = msg.tip
+	m.tipErr = msg.err
+	m.tipLoaded = true
+	return m
+}
 
-		if _, err := file.WriteString(entry); err != nil {
-			return saveErrorMsg{err: err}
-		}
+// currentTip is the coach's tip, or a general one when the coach couldn't
+// give one.
+func (m model) currentTip() string {
+	if m.tipErr != nil {
+		return coach.FallbackTip(m.startTime)
+	}
+	return m.tip
+}
 
-		return saveSuccessMsg{
-			noteFilePath: noteFilePath,
-			sessionCount: sessionCount,
+func (m model) tipErrorMessage() string {
+	switch {
+	case errors.Is(m.tipErr, coach.ErrNoSessions):
+		return "Log a few sessions and the coach will tailor its tips to them."
+	case errors.Is(m.tipErr, context.DeadlineExceeded):
+		return "The coach didn't answer in time, so here's a general tip."
+	}
+	return fmt.Sprintf("The coach is unavailable (%v), so here's a general tip.", m.tipErr)
+}
+
+// saveSession logs the session, skipping parts an earlier attempt saved.
+func (m *model) saveSession() tea.Cmd {
+	saved := len(m.logged)
+	return func() tea.Msg {
+		logged, err := m.notesConfig.LogSession(notes.Entry{
+			Start:         m.startTime,
+			End:           m.endTime,
+			Duration:      m.duration,
+			Milestone:     m.milestone,
+			FocusQuality:  m.focusQuality,
+			Interruptions: m.interruptions,
+			Reflection:    m.reflection,
+		}, saved)
+		if err != nil {
+			return saveErrorMsg{logged: logged, err: err}
 		}
+
+		return saveSuccessMsg{logged: logged}
 	}
 }
 
 func (m model) handleSaveSuccess(msg saveSuccessMsg) model {
-	m.noteFilePath = msg.noteFilePath
-	m.sessionCount = msg.sessionCount
+	m.logged = append(m.logged, msg.logged...)
 	m.state = stateDone
 	return m
 }
 
 func (m model) handleSaveError(msg saveErrorMsg) model {
+	m.logged = append(m.logged, msg.logged...)
 	m.err = msg.err
 	m.state = stateDone
 	return m
 }
+
+func (m model) saveErrorMessage() string {
+	// Parts of a split session saved before the error stay saved, and retry
+	// only writes the rest.
+	unwritten := "Nothing was written"
+	if len(m.logged) > 0 {
+		unwritten = "The rest of the session wasn't written"
+	}
+
+	switch {
+	case errors.Is(m.err, notes.ErrConflict):
+		return "Your daily note kept changing while saving (an editor or sync client is writing to it).\n" + unwritten + ". Wait for it to settle, then retry."
+	case errors.Is(m.err, notes.ErrLocked):
+		return "Another altum session is saving to this note right now.\n" + unwritten + ". Retry in a moment."
+	}
+	return fmt.Sprintf("Error saving session: %v", m.err)
+}
@@ -9,13 +9,15 @@ import (
 )
 
 type KeyMap struct {
-	Quit        key.Binding
-	stopSession key.Binding
-	Continue    key.Binding
-	Skip        key.Binding
-	Save        key.Binding
-	Back        key.Binding
-	Exit        key.Binding
+	Quit         key.Binding
+	startSession key.Binding
+	stopSession  key.Binding
+	Continue     key.Binding
+	Skip         key.Binding
+	Save         key.Binding
+	Back         key.Binding
+	Exit         key.Binding
+	Retry        key.Binding
 }
 
 var DefaultKeyMap = KeyMap{
@@ -23,6 +25,10 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("ctrl+c", "q"),
 		key.WithHelp("ctrl+c/q", "quit"),
 	),
+	startSession: key.NewBinding(
+		key.WithKeys("enter", " "),
+		key.WithHelp("enter/space", "start session"),
+	),
 	stopSession: key.NewBinding(
 		key.WithKeys("enter", " "),
 		key.WithHelp("enter/space", "stop session"),
@@ -47,6 +53,10 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("enter", "q"),
 		key.WithHelp("enter/q", "exit"),
 	),
+	Retry: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "retry save"),
+	),
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
@@ -55,13 +65,17 @@ func (k KeyMap) ShortHelp() []key.Binding {
 
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Quit, k.stopSession},
+		{k.Quit, k.startSession, k.stopSession},
 		{k.Continue, k.Skip},
 		{k.Save, k.Back},
-		{k.Exit},
+		{k.Exit, k.Retry},
 	}
 }
 
+func (k KeyMap) CoachHelp() []key.Binding {
+	return []key.Binding{k.startSession, k.Quit}
+}
+
 func (k KeyMap) sessionHelp() []key.Binding {
 	return []key.Binding{k.stopSession, k.Quit}
 }
@@ -86,6 +100,10 @@ func (k KeyMap) DoneHelp() []key.Binding {
 	return []key.Binding{k.Exit}
 }
 
+func (k KeyMap) SaveFailedHelp() []key.Binding {
+	return []key.Binding{k.Retry, k.Exit}
+}
+
 type stateKeyMap struct {
 	bindings []key.Binding
 }
@@ -98,6 +116,10 @@ func (k stateKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{k.bindings}
 }
 
+func (k KeyMap) CoachKeyMap() help.KeyMap {
+	return stateKeyMap{bindings: k.CoachHelp()}
+}
+
 func (k KeyMap) sessionKeyMap() help.KeyMap {
 	return stateKeyMap{bindings: k.sessionHelp()}
 }
@@ -122,6 +144,10 @@ func (k KeyMap) DoneKeyMap() help.KeyMap {
 	return stateKeyMap{bindings: k.DoneHelp()}
 }
 
+func (k KeyMap) SaveFailedKeyMap() help.KeyMap {
+	return stateKeyMap{bindings: k.SaveFailedHelp()}
+}
+
 func (k KeyMap) SavingKeyMap() help.KeyMap {
 	return stateKeyMap{bindings: []key.Binding{k.Quit}}
 }
@@ -13,12 +13,16 @@ import (
 	"github.com/charmbracelet/bubbles/stopwatch"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+
+	"altum/internal/coach"
+	"altum/internal/notes"
 )
 
 type sessionState int
 
 const (
-	stateSession sessionState = iota
+	stateCoach sessionState = iota
+	stateSession
 	stateMilestone
 	stateFocusQuality
 	stateInterruptions
@@ -38,19 +42,24 @@ type model struct {
 	keyMap             KeyMap
 	spinner            spinner.Model
 	startTime          time.Time
+	endTime            time.Time
 	duration           time.Duration
 	milestone          string
 	focusQuality       string
 	interruptions      string
 	reflection         string
-	dailyNotesPath     string
-	dateFormat         string
-	sessionCount       int
-	noteFilePath       string
+	notesConfig        notes.Config
+	logged             []notes.LoggedEntry
 	err                error
+	coach              *coach.Coach
+	tip                string
+	tipErr             error
+	tipLoaded          bool
 }
 
-func InitialModel(dailyNotesPath, dateFormat string) model {
+// InitialModel starts a session right away, or with a coach, opens on a tip
+// for the session and starts it once the tip has been read.
+func InitialModel(notesConfig notes.Config, sessionCoach *coach.Coach) model {
 	s := spinner.New()
 
 	sw := stopwatch.NewWithInterval(time.Second)
@@ -79,8 +88,13 @@ func InitialModel(dailyNotesPath, dateFormat string) model {
 	h := help.New()
 	h.Width = 80
 
+	state := stateSession
+	if sessionCoach != nil {
+		state = stateCoach
+	}
+
 	return model{
-		state:              stateSession,
+		state:              state,
 		stopwatch:          sw,
 		spinner:            s,
 		milestoneInput:     milestoneInput,
@@ -90,13 +104,16 @@ func InitialModel(dailyNotesPath, dateFormat string) model {
 		help:               h,
 		keyMap:             DefaultKeyMap,
 		startTime:          time.Now(),
-		dailyNotesPath:     dailyNotesPath,
-		dateFormat:         dateFormat,
+		notesConfig:        notesConfig,
 		focusQuality:       "3",
+		coach:              sessionCoach,
 	}
 }
 
 func (m model) Init() tea.Cmd {
+	if m.state == stateCoach {
+		return tea.Batch(m.spinner.Tick, m.fetchTip())
+	}
 	return tea.Batch(
 		m.stopwatch.Init(),
 		m.spinner.Tick,
@@ -116,8 +133,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m = m.handleSaveError(msg)
 		return m, nil
 
+	case tipMsg:
+		m = m.handleTip(msg)
+		return m, nil
+
 	case tea.KeyMsg:
 		switch m.state {
+		case stateCoach:
+			switch {
+			case key.Matches(msg, m.keyMap.Quit):
+				return m, tea.Quit
+			case key.Matches(msg, m.keyMap.startSession):
+				m.state = stateSession
+				m.startTime = time.Now()
+				return m, m.stopwatch.Init()
+			}
+
 		case stateSession:
 			switch {
 			case key.Matches(msg, m.keyMap.Quit):
@@ -125,6 +156,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, m.keyMap.stopSession):
 				m.state = stateMilestone
 				m.duration = m.stopwatch.Elapsed()
+				m.endTime = time.Now()
 				m.stopwatch.Stop()
 				m.milestoneInput.Focus()
 				return m, nil
@@ -206,6 +238,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case stateDone:
 			switch {
+			case m.err != nil && key.Matches(msg, m.keyMap.Retry):
+				m.err = nil
+				m.state = stateSaving
+				return m, m.saveSession()
 			case key.Matches(msg, m.keyMap.Quit), key.Matches(msg, m.keyMap.Exit):
 				return m, tea.Quit
 			}
@@ -213,6 +249,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	switch m.state {
+	case stateCoach:
+		m.spinner, cmd = m.spinner.Update(msg)
+		cmds = append(cmds, cmd)
+
 	case stateSession:
 		m.stopwatch, cmd = m.stopwatch.Update(msg)
 		cmds = append(cmds, cmd)
@@ -246,6 +286,21 @@ func (m model) View() string {
 	var s string
 
 	switch m.state {
+	case stateCoach:
+		s += TitleStyle.Render("Deep Work Coach")
+		s += "\n\n"
+		if !m.tipLoaded {
+			s += m.spinner.View() + " Looking over your recent sessions..."
+		} else {
+			s += TipStyle.Render(m.currentTip())
+			if m.tipErr != nil {
+				s += "\n\n"
+				s += MutedStyle.Render(m.tipErrorMessage())
+			}
+		}
+		s += "\n\n"
+		s += m.help.View(m.keyMap.CoachKeyMap())
+
 	case stateSession:
 		elapsed := m.stopwatch.Elapsed()
 		minutes := int(elapsed.Minutes())
@@ -261,6 +316,10 @@ func (m model) View() string {
 		s += "\n\n"
 		s += SessionTimerStyle.Render(m.spinner.View() + " " + fmt.Sprintf("%s", sessionTimerDisplay))
 		s += "\n\n"
+		if m.tipLoaded {
+			s += MutedStyle.Render(m.currentTip())
+			s += "\n\n"
+		}
 		s += m.help.View(m.keyMap.sessionKeyMap())
 
 	case stateMilestone:
@@ -328,17 +387,27 @@ func (m model) View() string {
 	case stateDone:
 		s += TitleStyle.Render("Session Complete")
 		s += "\n\n"
+		for _, logged := range m.logged {
+			s += SuccessStyle.Render(fmt.Sprintf("Session %d logged to: %s", logged.Number, logged.Path))
+			s += "\n"
+		}
 		if m.err != nil {
-			s += ErrorStyle.Render(fmt.Sprintf("Error saving session: %v", m.err))
+			if len(m.logged) > 0 {
+				s += "\n"
+			}
+			s += ErrorStyle.Render(m.saveErrorMessage())
 		} else {
-			s += SuccessStyle.Render(fmt.Sprintf("Session logged to: %s", m.noteFilePath))
-			s += "\n\n"
+			s += "\n"
 			minutes := int(m.duration.Minutes())
 			seconds := int(m.duration.Seconds()) % 60
 			s += fmt.Sprintf("Duration: %d minutes %d seconds\n", minutes, seconds)
 		}
 		s += "\n"
-		s += m.help.View(m.keyMap.DoneKeyMap())
+		if m.err != nil {
+			s += m.help.View(m.keyMap.SaveFailedKeyMap())
+		} else {
+			s += m.help.View(m.keyMap.DoneKeyMap())
+		}
 	}
 
 	return s
@@ -12,4 +12,6 @@ var (
 	ErrorStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Bold(true)
 	InputStyle        = lipgloss.NewStyle().BorderForeground(lipgloss.Color("8")).BorderStyle(lipgloss.RoundedBorder()).Padding(0, 1)
 	FocusedStyle      = lipgloss.NewStyle().BorderForeground(lipgloss.Color("7")).BorderStyle(lipgloss.RoundedBorder()).Padding(0, 1)
+	TipStyle          = lipgloss.NewStyle().Foreground(lipgloss.Color("7")).Width(78).PaddingLeft(2)
+	MutedStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Width(78).PaddingLeft(2)
 )